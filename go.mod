@@ -4,13 +4,17 @@ go 1.13
 
 require (
 	github.com/99designs/gqlgen v0.11.3
+	github.com/creack/pty v1.1.11
 	github.com/fsnotify/fsnotify v1.4.9
 	github.com/graphql-go/graphql v0.7.9
 	github.com/logrusorgru/aurora v0.0.0-20200102142835-e9ef32dff381
 	github.com/robfig/cron/v3 v3.0.1
 	github.com/spf13/cobra v0.0.6
 	github.com/vektah/gqlparser/v2 v2.0.1
+	golang.org/x/crypto v0.0.0-20191011191535-87dc89f01550
+	golang.org/x/sys v0.0.0-20200116001909-b77594299b42
 	golang.org/x/tools v0.0.0-20200114235610-7ae403b6b589
 	gopkg.in/yaml.v2 v2.2.8
+	gopkg.in/yaml.v3 v3.0.0-20200121175148-a6ecf24a6d71
 	mvdan.cc/sh v2.6.4+incompatible
 )
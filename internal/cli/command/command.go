@@ -10,6 +10,7 @@ import (
 	"github.com/jjzcru/elk/internal/cli/command/logs"
 	"github.com/jjzcru/elk/internal/cli/command/ls"
 	"github.com/jjzcru/elk/internal/cli/command/run"
+	"github.com/jjzcru/elk/internal/cli/command/validate"
 	"github.com/jjzcru/elk/internal/cli/command/version"
 	"github.com/spf13/cobra"
 )
@@ -34,6 +35,7 @@ func Execute() error {
 		execute.Command(),
 		cron.Command(),
 		logs.Command(),
+		validate.Command(),
 		server.NewServerCommand(),
 	)
 
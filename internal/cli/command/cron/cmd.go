@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/jjzcru/elk/internal/cli/command/run"
@@ -20,6 +21,8 @@ Flags:
   -d, --detached            Run the task in detached mode and returns the PGID
   -e, --env strings         Overwrite env variable in task
   -v, --var strings         Overwrite var variable in task   
+      --arg strings         Set an arg of the task as name=value
+      --profile string      Use a profile of the file
   -f, --file string         Run elk in a specific file
   -g, --global              Run from the path set in config
   -h, --help                Help for run
@@ -27,8 +30,11 @@ Flags:
       --ignore-log-format   Ignores format value in log
       --ignore-error        Ignore errors that happened during a task
       --ignore-deps         Ignore task dependencies
+      --force               Run the tasks even if they are up to date
+      --dry-run             Print the tasks that would run without running them
       --delay               Set a delay to a task
   -l, --log string          File that log output from a task
+  -j, --concurrency int     Maximum number of tasks running at the same time
   -w, --watch               Enable watch mode
   -t, --timeout             Set a timeout to a task
       --deadline            Set a deadline to a task
@@ -39,6 +45,7 @@ Flags:
 func Command() *cobra.Command {
 	var envs []string
 	var vars []string
+	var taskArgs []string
 	var cmd = &cobra.Command{
 		Use:   "cron",
 		Short: "Run one or more task as a cron job ⏱",
@@ -46,27 +53,28 @@ func Command() *cobra.Command {
 		Run: func(cmd *cobra.Command, args []string) {
 			err := run.Validate(cmd, args[1:])
 			if err != nil {
-				utils.PrintError(err)
-				return
+				utils.Exit(err)
 			}
 
-			err = Run(cmd, args, envs, vars)
-			if err != nil {
-				utils.PrintError(err)
-			}
+			utils.Exit(Run(cmd, args, envs, vars, taskArgs))
 		},
 	}
 
 	cmd.Flags().BoolP("global", "g", false, "")
 	cmd.Flags().StringSliceVarP(&envs, "env", "e", []string{}, "")
 	cmd.Flags().StringSliceVarP(&vars, "var", "v", []string{}, "")
+	cmd.Flags().StringSliceVar(&taskArgs, "arg", []string{}, "")
+	cmd.Flags().String("profile", "", "")
 	cmd.Flags().Bool("ignore-log-file", false, "")
 	cmd.Flags().Bool("ignore-log-format", false, "")
 	cmd.Flags().Bool("ignore-error", false, "")
 	cmd.Flags().Bool("ignore-deps", false, "")
+	cmd.Flags().Bool("force", false, "")
+	cmd.Flags().Bool("dry-run", false, "")
 	cmd.Flags().BoolP("detached", "d", false, "")
 	cmd.Flags().StringP("file", "f", "", "")
 	cmd.Flags().StringP("log", "l", "", "")
+	cmd.Flags().IntP("concurrency", "j", 0, "")
 	cmd.Flags().DurationP("timeout", "t", 0, "")
 	cmd.Flags().Duration("delay", 0, "")
 	cmd.Flags().String("deadline", "", "")
@@ -77,7 +85,7 @@ func Command() *cobra.Command {
 	return cmd
 }
 
-func Run(cmd *cobra.Command, args []string, envs []string, vars []string) error {
+func Run(cmd *cobra.Command, args []string, envs []string, vars []string, taskArgs []string) error {
 	isDetached, err := cmd.Flags().GetBool("detached")
 	if err != nil {
 		return err
@@ -93,6 +101,16 @@ func Run(cmd *cobra.Command, args []string, envs []string, vars []string) error
 		return err
 	}
 
+	isForce, err := cmd.Flags().GetBool("force")
+	if err != nil {
+		return err
+	}
+
+	isDryRun, err := cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		return err
+	}
+
 	delay, err := cmd.Flags().GetDuration("delay")
 	if err != nil {
 		return err
@@ -119,6 +137,11 @@ func Run(cmd *cobra.Command, args []string, envs []string, vars []string) error
 		return err
 	}
 
+	err = run.SetProfile(cmd, e)
+	if err != nil {
+		return err
+	}
+
 	logger, err := run.Build(cmd, e, args)
 	if err != nil {
 		return err
@@ -128,6 +151,8 @@ func Run(cmd *cobra.Command, args []string, envs []string, vars []string) error
 		Elk: e,
 		Executer: engine.DefaultExecuter{
 			Logger: logger,
+			OnSkip: utils.PrintSkipped,
+			Force:  isForce,
 		},
 	}
 
@@ -148,12 +173,30 @@ func Run(cmd *cobra.Command, args []string, envs []string, vars []string) error
 		clientEngine.Elk.Tasks[name] = task
 	}
 
+	err = run.SetArgs(clientEngine.Elk, args[1:], taskArgs, nil)
+	if err != nil {
+		return err
+	}
+
+	if isDryRun {
+		_, err = cron.ParseStandard(args[0])
+		if err != nil {
+			return err
+		}
+
+		return run.DryRun(clientEngine, args[1:]...)
+	}
+
 	if isDetached {
+		err = clientEngine.CheckInteractive(args[1:]...)
+		if err != nil {
+			return err
+		}
+
 		return run.Detached()
 	}
 
-	ctx := context.Background()
-	var cancel context.CancelFunc
+	ctx, cancel := run.WithSignals(context.Background())
 
 	if len(start) > 0 {
 		startTime, err := run.GetTimeFromString(start)
@@ -180,9 +223,7 @@ func Run(cmd *cobra.Command, args []string, envs []string, vars []string) error
 		ctx, cancel = context.WithDeadline(ctx, deadlineTime)
 	}
 
-	if cancel != nil {
-		defer cancel()
-	}
+	defer cancel()
 
 	cronTab := args[0]
 	tasks := args[1:]
@@ -191,15 +232,19 @@ func Run(cmd *cobra.Command, args []string, envs []string, vars []string) error
 
 	run.DelayStart(delay, start)
 
-	for _, task := range tasks {
-		go run.Task(ctx, clientEngine, task)
+	// The runs that are in progress are waited so their tasks stop gracefully
+	var wg sync.WaitGroup
+	executeTasks := func() {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			run.Task(ctx, clientEngine, tasks...)
+		}()
 	}
 
-	_, err = c.AddFunc(cronTab, func() {
-		for _, task := range tasks {
-			go run.Task(ctx, clientEngine, task)
-		}
-	})
+	executeTasks()
+
+	_, err = c.AddFunc(cronTab, executeTasks)
 	if err != nil {
 		return err
 	}
@@ -213,5 +258,6 @@ func Run(cmd *cobra.Command, args []string, envs []string, vars []string) error
 
 	<-ctx.Done()
 	c.Stop()
-	return nil
+	wg.Wait()
+	return run.Interrupted(ctx)
 }
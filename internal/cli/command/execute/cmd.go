@@ -3,8 +3,8 @@ package execute
 import (
 	"context"
 	"fmt"
+	"os"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/jjzcru/elk/internal/cli/command/run"
@@ -22,6 +22,7 @@ Flags:
   -e, --env strings        Overwrite env variable in commands
       --env-file string    Set an env file
   -v, --var strings        Overwrite var variable in commands
+      --profile string     Use the env and vars of a profile of the file
   -h, --help               Help for run
       --delay              Set a delay to a task
       --dir                Set a directory to the command
@@ -42,10 +43,7 @@ func Command() *cobra.Command {
 		Short: "Execute ad-hoc commands ⚡",
 		Args:  cobra.MinimumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
-			err := Run(cmd, args, envs, vars)
-			if err != nil {
-				utils.PrintError(err)
-			}
+			utils.Exit(Run(cmd, args, envs, vars))
 		},
 	}
 
@@ -54,6 +52,7 @@ func Command() *cobra.Command {
 	cmd.Flags().StringSliceVarP(&envs, "env", "e", []string{}, "")
 	cmd.Flags().String("env-file", "", "")
 	cmd.Flags().StringSliceVarP(&vars, "var", "v", []string{}, "")
+	cmd.Flags().String("profile", "", "")
 	cmd.Flags().Duration("delay", 0, "")
 	cmd.Flags().String("dir", "", "")
 	cmd.Flags().StringP("log", "l", "", "")
@@ -142,7 +141,7 @@ func Run(cmd *cobra.Command, args []string, envs []string, vars []string) error
 	elk := ox.Elk{
 		Tasks: map[string]ox.Task{
 			"elk": {
-				Cmds:        args,
+				Cmds:        ox.NewCmds(args...),
 				Dir:         dir,
 				EnvFile:     envFile,
 				Env:         make(map[string]string),
@@ -152,6 +151,22 @@ func Run(cmd *cobra.Command, args []string, envs []string, vars []string) error
 		},
 	}
 
+	profile, err := cmd.Flags().GetString("profile")
+	if err != nil {
+		return err
+	}
+
+	if len(profile) > 0 {
+		err = setProfile(&elk, profile)
+		if err != nil {
+			return err
+		}
+	} else if profile = os.Getenv(ox.ProfileEnv); len(profile) > 0 {
+		// The commands run without a file, so the profile from the env is
+		// ignored when there is no file or it does not declare the profile
+		_ = setProfile(&elk, profile)
+	}
+
 	logger, err := run.Build(cmd, &elk, []string{"elk"})
 	if err != nil {
 		return err
@@ -185,7 +200,7 @@ func Run(cmd *cobra.Command, args []string, envs []string, vars []string) error
 		return run.Detached()
 	}
 
-	ctx, cancel := context.WithCancel(context.Background())
+	ctx, cancel := run.WithSignals(context.Background())
 
 	if len(start) > 0 {
 		startTime, err := run.GetTimeFromString(start)
@@ -230,18 +245,37 @@ func Run(cmd *cobra.Command, args []string, envs []string, vars []string) error
 				go executeTasks()
 			case <-ctx.Done():
 				ticker.Stop()
+				err = run.Interrupted(ctx)
 				cancel()
-				return nil
+				return err
 			}
 		}
 	}
 
-	var wg sync.WaitGroup
+	_, err = clientEngine.Run(ctx, "elk")
+	err = utils.NewExitError(ctx, err)
+	cancel()
+	return err
+}
+
+// setProfile sets the env, vars and env_file of a profile of the ox file to the
+// commands
+func setProfile(elk *ox.Elk, name string) error {
+	e, err := utils.GetElk("", false)
+	if err != nil {
+		return err
+	}
 
-	wg.Add(1)
-	go run.TaskWG(ctx, clientEngine, "elk", &wg, false)
+	profile, err := e.GetProfile(name)
+	if err != nil {
+		return &utils.ConfigError{Err: err}
+	}
+
+	elk.Env = profile.Env
+	elk.EnvSh = profile.EnvSh
+	elk.Vars = profile.Vars
+	elk.VarsSh = profile.VarsSh
+	elk.EnvFile = e.GetPath(profile.EnvFile)
 
-	wg.Wait()
-	cancel()
 	return nil
 }
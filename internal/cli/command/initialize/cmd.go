@@ -79,8 +79,8 @@ func CreateElkFile(elkFilePath string) error {
 				Env: map[string]string{
 					"HELLO": "Hello",
 				},
-				Cmds: []string{
-					"echo $HELLO",
+				Cmds: []ox.Cmd{
+					{Cmd: "echo $HELLO"},
 				},
 			},
 			"test-log": {
@@ -88,14 +88,14 @@ func CreateElkFile(elkFilePath string) error {
 				Log: ox.Log{
 					Out: "./test.log",
 				},
-				Cmds: []string{
-					"echo $HELLO",
+				Cmds: []ox.Cmd{
+					{Cmd: "echo $HELLO"},
 				},
 			},
 			"ts-run": {
 				Description: "Run a typescript app",
-				Cmds: []string{
-					"npm start",
+				Cmds: []ox.Cmd{
+					{Cmd: "npm start"},
 				},
 				Deps: []ox.Dep{
 					{
@@ -107,20 +107,20 @@ func CreateElkFile(elkFilePath string) error {
 			"ts-build": {
 				Description: "Watch files and re-run to compile typescript",
 				Sources:     "[a-zA-Z]*.ts$",
-				Cmds: []string{
-					"npm run build",
+				Cmds: []ox.Cmd{
+					{Cmd: "npm run build"},
 				},
 			},
 			"shutdown": {
 				Description: "Command to shutdown the machine",
-				Cmds: []string{
-					shutdown,
+				Cmds: []ox.Cmd{
+					{Cmd: shutdown},
 				},
 			},
 			"restart": {
 				Description: "Command that should restart the machine",
-				Cmds: []string{
-					restart,
+				Cmds: []ox.Cmd{
+					{Cmd: restart},
 				},
 			},
 		},
@@ -0,0 +1,33 @@
+package init
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jjzcru/elk/pkg/primitives/ox"
+)
+
+func TestCreateElkFileIsValid(t *testing.T) {
+	dir, err := ioutil.TempDir("", "elk-init-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "ox.yml")
+	err = CreateElkFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	validationErrors, err := ox.Validate(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, validationError := range validationErrors {
+		t.Errorf("The file created by init should be valid: %v", validationError)
+	}
+}
@@ -83,6 +83,9 @@ func run(cmd *cobra.Command, args []string) error {
 			return err
 		}
 
+		task.Log.Out = e.GetPath(task.Log.Out)
+		task.Log.Err = e.GetPath(task.Log.Err)
+
 		f, err := os.Open(task.Log.Out)
 		if err != nil {
 			return err
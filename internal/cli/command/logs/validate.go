@@ -22,6 +22,9 @@ func validate(cmd *cobra.Command, args []string) error {
 			return err
 		}
 
+		task.Log.Out = e.GetPath(task.Log.Out)
+		task.Log.Err = e.GetPath(task.Log.Err)
+
 		if len(task.Log.Out) == 0 {
 			return fmt.Errorf("task '%s' do not have a log file", name)
 		}
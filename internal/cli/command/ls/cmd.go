@@ -77,7 +77,7 @@ func run(cmd *cobra.Command, _ []string) error {
 }
 
 func printAll(w *tabwriter.Writer, e *ox.Elk) error {
-	_, err := fmt.Fprintf(w, "\n%s\t%s\t%s\t\n", "TASK NAME", "DESCRIPTION", "DEPENDENCIES")
+	_, err := fmt.Fprintf(w, "\n%s\t%s\t%s\t%s\t\n", "TASK NAME", "DESCRIPTION", "DEPENDENCIES", "ARGS")
 	if err != nil {
 		return err
 	}
@@ -89,7 +89,12 @@ func printAll(w *tabwriter.Writer, e *ox.Elk) error {
 			deps = append(deps, dep.Name)
 		}
 
-		_, err = fmt.Fprintf(w, "%s\t%s\t%s\t\n", taskName, task.Description, strings.Join(deps, ", "))
+		var args []string
+		for _, arg := range task.Args {
+			args = append(args, formatArg(arg))
+		}
+
+		_, err = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t\n", taskName, task.Description, strings.Join(deps, ", "), strings.Join(args, ", "))
 		if err != nil {
 			return err
 		}
@@ -98,6 +103,26 @@ func printAll(w *tabwriter.Writer, e *ox.Elk) error {
 	return nil
 }
 
+// formatArg returns an arg as name:type=default, enums display their values
+// as the type and required args end with *
+func formatArg(arg ox.Arg) string {
+	argType := arg.GetType()
+	if argType == ox.ArgEnum {
+		argType = strings.Join(arg.Values, "|")
+	}
+
+	value := fmt.Sprintf("%s:%s", arg.Name, argType)
+	if len(arg.Default) > 0 {
+		value = fmt.Sprintf("%s=%s", value, arg.Default)
+	}
+
+	if arg.Required {
+		value += "*"
+	}
+
+	return value
+}
+
 func printPlain(w *tabwriter.Writer, elk *ox.Elk) error {
 	_, err := fmt.Fprintf(w, "\n%s\t%s\t\n", "TASK NAME", "DESCRIPTION")
 	if err != nil {
@@ -0,0 +1,36 @@
+package run
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jjzcru/elk/pkg/primitives/ox"
+	"github.com/spf13/cobra"
+)
+
+// SplitArgs returns the arguments of the command before `--` and the
+// positional args of the task that are after it
+func SplitArgs(cmd *cobra.Command, args []string) ([]string, []string) {
+	dash := cmd.ArgsLenAtDash()
+	if dash < 0 {
+		return args, nil
+	}
+
+	return args[:dash], args[dash:]
+}
+
+// SetArgs sets the args of the tasks from the values of the --arg flag, with
+// the form name=value, and the positional args
+func SetArgs(e *ox.Elk, tasks []string, values []string, positional []string) error {
+	args := make(map[string]string)
+	for _, value := range values {
+		parts := strings.SplitN(value, "=", 2)
+		if len(parts) != 2 {
+			return fmt.Errorf("%w: '%s' should have the form name=value", ox.ErrInvalidArgValue, value)
+		}
+
+		args[parts[0]] = parts[1]
+	}
+
+	return e.SetArgs(tasks, args, positional)
+}
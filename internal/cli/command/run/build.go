@@ -5,7 +5,6 @@ import (
 	"github.com/jjzcru/elk/pkg/engine"
 	"os"
 	"path/filepath"
-	"time"
 
 	"github.com/jjzcru/elk/pkg/primitives/ox"
 	"github.com/jjzcru/elk/pkg/utils"
@@ -36,6 +35,16 @@ func Build(cmd *cobra.Command, e *ox.Elk, tasks []string) (map[string]engine.Log
 		ignoreDep = false
 	}
 
+	isDryRun, err := cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		isDryRun = false
+	}
+
+	concurrency, err := cmd.Flags().GetInt("concurrency")
+	if err != nil {
+		concurrency = 0
+	}
+
 	logFilePath, err := cmd.Flags().GetString("log")
 	if err != nil {
 		return logger, err
@@ -53,6 +62,10 @@ func Build(cmd *cobra.Command, e *ox.Elk, tasks []string) (map[string]engine.Log
 		e.Env = make(map[string]string)
 	}
 
+	if concurrency > 0 {
+		e.Concurrency = concurrency
+	}
+
 	if len(logFilePath) > 0 {
 		_, err := os.OpenFile(logFilePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
 		if err != nil {
@@ -72,18 +85,19 @@ func Build(cmd *cobra.Command, e *ox.Elk, tasks []string) (map[string]engine.Log
 		taskMaps[task] = true
 	}
 
+	// The flags apply to the inherited properties so the tasks are extended
+	// before, the matrix is expanded by build so its instances get the flags
+	err = e.ResolveExtends()
+	if err != nil {
+		return logger, &utils.ConfigError{Err: err}
+	}
+
+	var matrix []string
 	for name, task := range e.Tasks {
 		if _, ok := taskMaps[name]; !ok {
 			continue
 		}
 
-		taskLogger := engine.DefaultLogger()
-
-		if ignoreLogFile {
-			logger[name] = taskLogger
-			continue
-		}
-
 		if len(logFilePath) > 0 {
 			task.Log = ox.Log{
 				Out: logFilePath,
@@ -91,6 +105,56 @@ func Build(cmd *cobra.Command, e *ox.Elk, tasks []string) (map[string]engine.Log
 			}
 		}
 
+		if ignoreError {
+			task.IgnoreError = true
+		}
+
+		if ignoreDep {
+			task.Deps = []ox.Dep{}
+		}
+
+		if len(task.Matrix) > 0 {
+			matrix = append(matrix, name)
+		}
+
+		e.Tasks[name] = task
+	}
+
+	err = e.Build()
+	if err != nil {
+		return logger, &utils.ConfigError{Err: err}
+	}
+
+	// A dry run does not run anything, the values declared as shell commands
+	// are placeholders
+	if isDryRun {
+		e.PlaceholderSh(tasks...)
+	} else {
+		err = e.EvalSh(tasks...)
+		if err != nil {
+			return logger, &utils.ConfigError{Err: err}
+		}
+	}
+
+	// A matrix task depends on its instances, they log like the task
+	for _, name := range matrix {
+		for _, dep := range e.Tasks[name].Deps {
+			taskMaps[dep.Name] = true
+		}
+	}
+
+	for name, task := range e.Tasks {
+		if _, ok := taskMaps[name]; !ok {
+			continue
+		}
+
+		taskLogger := engine.DefaultLogger()
+
+		if ignoreLogFile {
+			logger[name] = taskLogger
+			continue
+		}
+
 		if len(task.Log.Out) > 0 {
 			logFile, err := os.OpenFile(task.Log.Out, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
 			if err != nil {
@@ -124,59 +188,11 @@ func Build(cmd *cobra.Command, e *ox.Elk, tasks []string) (map[string]engine.Log
 		}
 
 		logger[name] = taskLogger
-
-		if ignoreError {
-			task.IgnoreError = true
-		}
-
-		if ignoreDep {
-			task.Deps = []ox.Dep{}
-		}
-
-		e.Tasks[name] = task
-	}
-
-	err = e.Build()
-	if err != nil {
-		return logger, err
 	}
 
 	return logger, nil
 }
 
 func getDateFormat(format string) (string, error) {
-	switch format {
-	case "ANSIC":
-		fallthrough
-	case "ansic":
-		return time.ANSIC, nil
-	case "UnixDate":
-		fallthrough
-	case "unixdate":
-		return time.UnixDate, nil
-	case "rubydate":
-		fallthrough
-	case "RubyDate":
-		return time.RubyDate, nil
-	case "RFC822":
-		return time.RFC822, nil
-	case "RFC822Z":
-		return time.RFC822Z, nil
-	case "RFC850":
-		return time.RFC850, nil
-	case "RFC1123":
-		return time.RFC1123, nil
-	case "RFC1123Z":
-		return time.RFC1123Z, nil
-	case "RFC3339":
-		return time.RFC3339, nil
-	case "RFC3339Nano":
-		return time.RFC3339Nano, nil
-	case "kitchen":
-		fallthrough
-	case "Kitchen":
-		return time.Kitchen, nil
-	default:
-		return "", fmt.Errorf("%s is an invalid timestamp format", format)
-	}
+	return ox.GetLogFormat(format)
 }
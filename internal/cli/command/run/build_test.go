@@ -0,0 +1,123 @@
+package run
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jjzcru/elk/pkg/engine"
+	"github.com/jjzcru/elk/pkg/primitives/ox"
+)
+
+const extendsElk = `
+tasks:
+  dep:
+    cmds:
+      - echo DEP
+  base:
+    log:
+      out: ./base.log
+    deps:
+      - name: dep
+    cmds:
+      - echo BASE
+  child:
+    extends: base
+    cmds:
+      - echo CHILD
+`
+
+func buildAndRun(t *testing.T, content string, flags map[string]string, tasks ...string) string {
+	dir, err := ioutil.TempDir("", "elk-build-")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(dir, "ox.yml")
+	err = ioutil.WriteFile(path, []byte(content), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e, err := ox.FromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := Command()
+	for flag, value := range flags {
+		err = cmd.Flags().Set(flag, value)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	logger, err := Build(cmd, e, tasks)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clientEngine := &engine.Engine{
+		Elk:      e,
+		Executer: engine.DefaultExecuter{Logger: logger},
+	}
+
+	_, err = clientEngine.Run(context.Background(), tasks...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return dir
+}
+
+func TestBuildExtendsLog(t *testing.T) {
+	dir := buildAndRun(t, extendsElk, nil, "child")
+	defer os.RemoveAll(dir)
+
+	content, err := ioutil.ReadFile(filepath.Join(dir, "base.log"))
+	if err != nil {
+		t.Fatalf("The log inherited from base should be used: %v", err)
+	}
+
+	if string(content) != "CHILD\n" {
+		t.Errorf("The log should be '%s' but it was '%s' instead", "CHILD\n", string(content))
+	}
+}
+
+func TestBuildIgnoreDepsExtends(t *testing.T) {
+	dir := buildAndRun(t, strings.Replace(extendsElk, "echo DEP", "echo DEP >> dep.log", 1), map[string]string{
+		"ignore-deps": "true",
+	}, "child")
+	defer os.RemoveAll(dir)
+
+	_, err := os.Stat(filepath.Join(dir, "dep.log"))
+	if err == nil {
+		t.Error("The inherited deps should not run with ignore deps")
+	}
+}
+
+func TestBuildMatrixLog(t *testing.T) {
+	dir := buildAndRun(t, `
+tasks:
+  test:
+    matrix:
+      go: [1.13]
+    log:
+      out: ./test.log
+    cmds:
+      - echo {{.go}}
+`, nil, "test")
+	defer os.RemoveAll(dir)
+
+	content, err := ioutil.ReadFile(filepath.Join(dir, "test.log"))
+	if err != nil {
+		t.Fatalf("The instances should use the log of the task: %v", err)
+	}
+
+	if string(content) != "1.13\n" {
+		t.Errorf("The log should be '%s' but it was '%s' instead", "1.13\n", string(content))
+	}
+}
@@ -16,12 +16,14 @@ import (
 )
 
 var usageTemplate = `Usage:
-  elk run [tasks] [flags]
+  elk run [tasks] [flags] [-- args]
 
 Flags:
   -d, --detached            Run the task in detached mode and returns the PGID
   -e, --env strings         Overwrite env variable in task
   -v, --var strings         Overwrite var variable in task
+      --arg strings         Set an arg of the task as name=value
+      --profile string      Use a profile of the file
   -f, --file string         Run elk in a specific file
   -g, --global              Run from the path set in config
   -h, --help                Help for run
@@ -29,8 +31,11 @@ Flags:
       --ignore-log-format   Ignores format value in log
       --ignore-error        Ignore errors that happened during a task
       --ignore-deps         Ignore task dependencies
+      --force               Run the tasks even if they are up to date
+      --dry-run             Print the tasks that would run without running them
       --delay               Set a delay to a task
   -l, --log string          File that log output from a task
+  -j, --concurrency int     Maximum number of tasks running at the same time
   -w, --watch               Enable watch mode
   -t, --timeout             Set a timeout to a task
       --deadline            Set a deadline to a task
@@ -42,35 +47,42 @@ Flags:
 func Command() *cobra.Command {
 	var envs []string
 	var vars []string
+	var taskArgs []string
 	var cmd = &cobra.Command{
 		Use:   "run",
 		Short: "Run one or more tasks 🤖",
 		Args:  cobra.MinimumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
-			err := Validate(cmd, args)
-			if err != nil {
-				utils.PrintError(err)
-				return
+			tasks, positional := SplitArgs(cmd, args)
+			if len(tasks) == 0 {
+				utils.Exit(errors.New("requires at least 1 task"))
 			}
 
-			err = run(cmd, args, envs, vars)
+			err := Validate(cmd, tasks)
 			if err != nil {
-				utils.PrintError(err)
+				utils.Exit(err)
 			}
+
+			utils.Exit(run(cmd, tasks, envs, vars, taskArgs, positional))
 		},
 	}
 
 	cmd.Flags().BoolP("global", "g", false, "")
 	cmd.Flags().StringSliceVarP(&envs, "env", "e", []string{}, "")
 	cmd.Flags().StringSliceVarP(&vars, "var", "v", []string{}, "")
+	cmd.Flags().StringSliceVar(&taskArgs, "arg", []string{}, "")
+	cmd.Flags().String("profile", "", "")
 	cmd.Flags().Bool("ignore-log-file", false, "")
 	cmd.Flags().Bool("ignore-log-format", false, "")
 	cmd.Flags().Bool("ignore-error", false, "")
 	cmd.Flags().Bool("ignore-deps", false, "")
+	cmd.Flags().Bool("force", false, "")
+	cmd.Flags().Bool("dry-run", false, "")
 	cmd.Flags().BoolP("detached", "d", false, "")
 	cmd.Flags().BoolP("watch", "w", false, "")
 	cmd.Flags().StringP("file", "f", "", "")
 	cmd.Flags().StringP("log", "l", "", "")
+	cmd.Flags().IntP("concurrency", "j", 0, "")
 	cmd.Flags().DurationP("timeout", "t", 0, "")
 	cmd.Flags().Duration("delay", 0, "")
 	cmd.Flags().String("deadline", "", "")
@@ -82,7 +94,7 @@ func Command() *cobra.Command {
 	return cmd
 }
 
-func run(cmd *cobra.Command, args []string, envs []string, vars []string) error {
+func run(cmd *cobra.Command, args []string, envs []string, vars []string, taskArgs []string, positional []string) error {
 	isDetached, err := cmd.Flags().GetBool("detached")
 	if err != nil {
 		return err
@@ -103,6 +115,16 @@ func run(cmd *cobra.Command, args []string, envs []string, vars []string) error
 		return err
 	}
 
+	isForce, err := cmd.Flags().GetBool("force")
+	if err != nil {
+		return err
+	}
+
+	isDryRun, err := cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		return err
+	}
+
 	delay, err := cmd.Flags().GetDuration("delay")
 	if err != nil {
 		return err
@@ -134,6 +156,11 @@ func run(cmd *cobra.Command, args []string, envs []string, vars []string) error
 		return err
 	}
 
+	err = SetProfile(cmd, e)
+	if err != nil {
+		return err
+	}
+
 	logger, err := Build(cmd, e, args)
 	if err != nil {
 		return err
@@ -143,6 +170,8 @@ func run(cmd *cobra.Command, args []string, envs []string, vars []string) error
 		Elk: e,
 		Executer: engine.DefaultExecuter{
 			Logger: logger,
+			OnSkip: utils.PrintSkipped,
+			Force:  isForce,
 		},
 	}
 
@@ -162,11 +191,25 @@ func run(cmd *cobra.Command, args []string, envs []string, vars []string) error
 		clientEngine.Elk.Tasks[name] = task
 	}
 
+	err = SetArgs(clientEngine.Elk, args, taskArgs, positional)
+	if err != nil {
+		return err
+	}
+
+	if isDryRun {
+		return DryRun(clientEngine, args...)
+	}
+
 	if isDetached {
+		err = clientEngine.CheckInteractive(args...)
+		if err != nil {
+			return err
+		}
+
 		return Detached()
 	}
 
-	ctx, cancel := context.WithCancel(context.Background())
+	ctx, cancel := WithSignals(context.Background())
 
 	if len(start) > 0 {
 		startTime, err := GetTimeFromString(start)
@@ -199,26 +242,38 @@ func run(cmd *cobra.Command, args []string, envs []string, vars []string) error
 	DelayStart(delay, start)
 
 	if interval > 0 {
+		var wg sync.WaitGroup
 		executeTasks := func() {
-			for _, task := range args {
-				go TaskWG(ctx, clientEngine, task, nil, false)
-			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				Task(ctx, clientEngine, args...)
+			}()
 		}
 
-		go executeTasks()
+		executeTasks()
 		ticker := time.NewTicker(interval)
 		for {
 			select {
 			case <-ticker.C:
-				go executeTasks()
+				executeTasks()
 			case <-ctx.Done():
 				ticker.Stop()
+				wg.Wait()
+				err = Interrupted(ctx)
 				cancel()
-				return nil
+				return err
 			}
 		}
 	}
 
+	if !isWatch {
+		_, err = clientEngine.Run(ctx, args...)
+		err = utils.NewExitError(ctx, err)
+		cancel()
+		return err
+	}
+
 	var wg sync.WaitGroup
 	for _, task := range args {
 		wg.Add(1)
@@ -226,9 +281,10 @@ func run(cmd *cobra.Command, args []string, envs []string, vars []string) error
 	}
 
 	wg.Wait()
+	err = Interrupted(ctx)
 	cancel()
 
-	return nil
+	return err
 }
 
 // DelayStart sleep the program by an amount of time
@@ -328,13 +384,20 @@ func TaskWG(ctx context.Context, cliEngine *engine.Engine, task string, wg *sync
 	Task(ctx, cliEngine, task)
 }
 
-// Task runs a task on the engine
-func Task(ctx context.Context, cliEngine *engine.Engine, task string) {
+// Task runs one or more tasks on the engine
+func Task(ctx context.Context, cliEngine *engine.Engine, tasks ...string) {
 	ctx, cancel := context.WithCancel(ctx)
 
-	err := cliEngine.Run(ctx, task)
+	_, err := cliEngine.Run(ctx, tasks...)
 	cancel()
 	if err != nil {
+		if errs, ok := err.(engine.Errors); ok {
+			for _, err := range errs {
+				utils.PrintError(err)
+			}
+			return
+		}
+
 		utils.PrintError(err)
 		return
 	}
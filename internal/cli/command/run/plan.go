@@ -0,0 +1,65 @@
+package run
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jjzcru/elk/pkg/engine"
+	"github.com/logrusorgru/aurora"
+)
+
+// DryRun prints the tasks in the order that they would run without running them
+func DryRun(cliEngine *engine.Engine, tasks ...string) error {
+	plan, err := cliEngine.DryRun(tasks...)
+	if err != nil {
+		return err
+	}
+
+	for i, task := range plan {
+		fmt.Printf("%d. %s", i+1, aurora.Bold(task.Task))
+		if task.Detached {
+			fmt.Print(aurora.Yellow(" (detached)"))
+		}
+		fmt.Println()
+
+		fmt.Printf("   dir: %s\n", task.Dir)
+
+		if len(task.Deps) > 0 {
+			fmt.Printf("   deps: %s\n", strings.Join(task.Deps, ", "))
+		}
+
+		if len(task.Env) > 0 {
+			var keys []string
+			for k := range task.Env {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+
+			fmt.Println("   env:")
+			for _, k := range keys {
+				fmt.Printf("     %s=%s\n", k, task.Env[k])
+			}
+		}
+
+		if len(task.Cmds) > 0 {
+			fmt.Println("   cmds:")
+			for _, cmd := range task.Cmds {
+				fmt.Printf("     %s\n", cmd)
+			}
+		}
+
+		if len(task.Hooks) > 0 {
+			fmt.Println("   hooks:")
+			for _, hook := range task.Hooks {
+				if len(hook.Task) > 0 {
+					fmt.Printf("     %s: task %s\n", hook.Type, hook.Task)
+				} else {
+					fmt.Printf("     %s: %s\n", hook.Type, hook.Cmd)
+				}
+			}
+		}
+	}
+
+	return nil
+}
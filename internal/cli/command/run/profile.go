@@ -0,0 +1,39 @@
+package run
+
+import (
+	"os"
+
+	"github.com/jjzcru/elk/pkg/primitives/ox"
+	"github.com/jjzcru/elk/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// GetProfile returns the profile set with the flag --profile or with the env
+// variable ELK_PROFILE
+func GetProfile(cmd *cobra.Command) (string, error) {
+	profile, err := cmd.Flags().GetString("profile")
+	if err != nil {
+		return "", err
+	}
+
+	if len(profile) == 0 {
+		profile = os.Getenv(ox.ProfileEnv)
+	}
+
+	return profile, nil
+}
+
+// SetProfile applies the profile set by the user to the file
+func SetProfile(cmd *cobra.Command, e *ox.Elk) error {
+	profile, err := GetProfile(cmd)
+	if err != nil {
+		return err
+	}
+
+	err = e.SetProfile(profile)
+	if err != nil {
+		return &utils.ConfigError{Err: err}
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package run
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/jjzcru/elk/pkg/utils"
+)
+
+// WithSignals returns a context that is cancelled when elk receives an
+// interrupt or a termination signal, so the tasks are stopped gracefully and
+// their hooks run. A second signal stops elk right away
+func WithSignals(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		select {
+		case <-signals:
+			cancel()
+		case <-ctx.Done():
+		}
+
+		signal.Stop(signals)
+	}()
+
+	return ctx, cancel
+}
+
+// Interrupted returns an error with the exit code for a cancelled run if the
+// context was cancelled by a signal, runs that end by a timeout or a deadline
+// do not return an error
+func Interrupted(ctx context.Context) error {
+	if ctx.Err() != context.Canceled {
+		return nil
+	}
+
+	return utils.NewExitError(ctx, ctx.Err())
+}
@@ -26,6 +26,11 @@ func Validate(cmd *cobra.Command, tasks []string) error {
 		}
 	}
 
+	concurrency, err := cmd.Flags().GetInt("concurrency")
+	if err == nil && concurrency < 0 {
+		return ox.ErrInvalidConcurrency
+	}
+
 	isWatch, err := cmd.Flags().GetBool("watch")
 	if err != nil {
 		isWatch = false
@@ -47,11 +52,26 @@ func Validate(cmd *cobra.Command, tasks []string) error {
 		return err
 	}
 
+	err = SetProfile(cmd, e)
+	if err != nil {
+		return err
+	}
+
+	err = e.ResolveExtends()
+	if err != nil {
+		return &utils.ConfigError{Err: err}
+	}
+
+	err = e.ExpandMatrix()
+	if err != nil {
+		return &utils.ConfigError{Err: err}
+	}
+
 	for _, name := range tasks {
 		task, err := e.GetTask(name)
 		if err != nil {
 			if err == ox.ErrTaskNotFound {
-				return fmt.Errorf("task \"%s\" not found", name)
+				return fmt.Errorf("%w: %s", ox.ErrTaskNotFound, name)
 			}
 			return err
 		}
@@ -38,7 +38,7 @@ func Watch(ctx context.Context, cliEngine *engine.Engine, task string, t ox.Task
 	}
 
 	runOnWatch := func() {
-		err := cliEngine.Run(taskCtx, task)
+		_, err := cliEngine.Run(taskCtx, task)
 		if err != nil {
 			utils.PrintError(err)
 		}
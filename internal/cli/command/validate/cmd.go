@@ -0,0 +1,91 @@
+package validate
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jjzcru/elk/pkg/primitives/ox"
+	"github.com/jjzcru/elk/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+var usageTemplate = `Usage:
+  elk validate [flags]
+
+Flags:
+  -f, --file string   Specify the file to used
+  -g, --global        Validate the global file
+  -h, --help          help for validate
+      --schema        Print the JSON Schema of the file
+`
+
+// Command returns a cobra command for `validate` sub command
+func Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Validate a file without running it",
+		Run: func(cmd *cobra.Command, args []string) {
+			err := run(cmd, args)
+			if err != nil {
+				utils.Exit(err)
+			}
+		},
+	}
+
+	cmd.Flags().StringP("file", "f", "", "")
+	cmd.Flags().BoolP("global", "g", false, "")
+	cmd.Flags().Bool("schema", false, "")
+
+	cmd.SetUsageTemplate(usageTemplate)
+
+	return cmd
+}
+
+func run(cmd *cobra.Command, _ []string) error {
+	isGlobal, err := cmd.Flags().GetBool("global")
+	if err != nil {
+		return err
+	}
+
+	elkFilePath, err := cmd.Flags().GetString("file")
+	if err != nil {
+		return err
+	}
+
+	printSchema, err := cmd.Flags().GetBool("schema")
+	if err != nil {
+		return err
+	}
+
+	if printSchema {
+		schema, err := ox.JSONSchema()
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(string(schema))
+		return nil
+	}
+
+	elkFilePath, err = utils.GetElkFilePath(elkFilePath, isGlobal)
+	if err != nil {
+		return err
+	}
+
+	validationErrors, err := ox.Validate(elkFilePath)
+	if err != nil {
+		return &utils.ConfigError{Err: fmt.Errorf("%s: %w", elkFilePath, err)}
+	}
+
+	if len(validationErrors) == 0 {
+		fmt.Printf("%s is valid\n", elkFilePath)
+		return nil
+	}
+
+	for _, validationError := range validationErrors {
+		utils.PrintError(fmt.Errorf("%s:%w", elkFilePath, validationError))
+	}
+
+	os.Exit(utils.ExitConfig)
+	return nil
+}
@@ -2,8 +2,10 @@ package engine
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/jjzcru/elk/pkg/primitives/ox"
 )
@@ -12,19 +14,183 @@ import (
 type Engine struct {
 	Elk      *ox.Elk
 	Executer Executer
+
+	// Observers receive the events of each run
+	Observers []Observer
+
+	// NonInteractive refuses to run interactive tasks, it is used when there
+	// is no terminal attached to the run
+	NonInteractive bool
+}
+
+// Subscribe adds an observer that receives the events of each run
+func (e *Engine) Subscribe(observer Observer) {
+	e.Observers = append(e.Observers, observer)
 }
 
-// Run task declared in ox.yml file
-func (e *Engine) Run(ctx context.Context, task string) error {
-	if !e.Elk.HasTask(task) {
-		return fmt.Errorf("task '%s' not found", task)
+// Run tasks declared in ox.yml file, each task and dependency runs at most once.
+// It returns the result of each task in the same order
+func (e *Engine) Run(ctx context.Context, tasks ...string) ([]*Result, error) {
+	for _, task := range tasks {
+		if !e.Elk.HasTask(task) {
+			return nil, fmt.Errorf("%w: %s", ox.ErrTaskNotFound, task)
+		}
+	}
+
+	plan, err := e.Plan(tasks...)
+	if err != nil {
+		return nil, err
+	}
+
+	// The hook tasks run with their deps, a task that waits for itself
+	// through them would never finish
+	for _, task := range tasks {
+		err = e.Elk.HasCircularHook(task)
+		if errors.Is(err, ox.ErrCircularDependency) {
+			return nil, err
+		}
+	}
+
+	err = e.Elk.EvalSh(tasks...)
+	if err != nil {
+		return nil, err
 	}
 
-	_, err := e.Executer.Execute(ctx, e.Elk, task)
+	// The vars are checked before any task runs, including the tasks used
+	// as hooks
+	plan, err = e.planHooks(plan)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range plan {
+		task := e.Elk.Tasks[name]
+		err = task.CheckVars()
+		if err != nil {
+			return nil, &TaskError{Task: name, Err: err}
+		}
+	}
+
+	if e.NonInteractive {
+		err = e.CheckInteractive(tasks...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// The outputs are shared by all the tasks of the run
+	ctx = withOutputs(ctx, newOutputs())
+
+	ev := newEvents(e.Observers)
+	ctx = withEvents(ctx, ev)
+	ev.emit(Event{Type: RunStarted})
+
+	s := newScheduler(ctx, e.Elk, e.Executer)
+
+	results := make([]*Result, len(tasks))
+	errs := make([]error, len(tasks))
+	var wg sync.WaitGroup
+	for i, task := range tasks {
+		wg.Add(1)
+		go func(i int, task string) {
+			defer wg.Done()
+			results[i], errs[i] = s.run(ctx, task)
+		}(i, task)
+	}
+
+	wg.Wait()
+
+	// When the run is cancelled the tasks are still stopping
+	s.wait()
+
+	for i, err := range errs {
+		if err != nil {
+			errs[i] = &TaskError{
+				Task: tasks[i],
+				Err:  err,
+			}
+		}
+	}
+
+	// The tasks that depend on a task that failed return its error too, so
+	// the failure is reported once
+	err = collectErrors(uniqueErrors(append(errs, s.detachedErrors()...)))
+
+	status, exitCode := GetStatus(ctx, err)
+	ev.emit(Event{
+		Type:     RunFinished,
+		Status:   status,
+		ExitCode: exitCode,
+	})
+
+	return results, err
+}
+
+// Plan returns the tasks and its dependencies in the order that they can run
+func (e *Engine) Plan(tasks ...string) ([]string, error) {
+	var plan []string
+
+	visited := make(map[string]bool)
+
+	// path are the tasks being visited, used to display the cycle
+	var path []string
+
+	var visit func(name string) error
+	visit = func(name string) error {
+		if visited[name] {
+			return nil
+		}
+
+		for i, node := range path {
+			if node == name {
+				cycle := append(append([]string{}, path[i:]...), name)
+				return fmt.Errorf("%w: %s", ox.ErrCircularDependency, strings.Join(cycle, " -> "))
+			}
+		}
+
+		task, exists := e.Elk.Tasks[name]
+		if !exists {
+			return ox.ErrTaskNotFound
+		}
+
+		path = append(path, name)
+		for _, dep := range task.Deps {
+			err := visit(dep.Name)
+			if err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+
+		visited[name] = true
+		plan = append(plan, name)
+		return nil
+	}
+
+	for _, task := range tasks {
+		err := visit(task)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return plan, nil
+}
+
+// CheckInteractive returns an error if one of the tasks or its dependencies is
+// interactive, it is used before the tasks run without a terminal
+func (e *Engine) CheckInteractive(tasks ...string) error {
+	plan, err := e.Plan(tasks...)
 	if err != nil {
 		return err
 	}
 
+	for _, name := range plan {
+		if e.Elk.Tasks[name].Interactive {
+			return &InteractiveError{Task: name}
+		}
+	}
+
 	return nil
 }
 
@@ -2,9 +2,17 @@ package engine
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	elk2 "github.com/jjzcru/elk/pkg/primitives/ox"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 func getTestEngine() *Engine {
@@ -13,8 +21,8 @@ func getTestEngine() *Engine {
 		Tasks: map[string]elk2.Task{
 			"hello": {
 				Description: "Empty Task",
-				Cmds: []string{
-					"echo Hello",
+				Cmds: []elk2.Cmd{
+					{Cmd: "echo Hello"},
 				},
 			},
 			"world": {
@@ -26,8 +34,8 @@ func getTestEngine() *Engine {
 				Env: map[string]string{
 					"FOO": "BAR",
 				},
-				Cmds: []string{
-					"echo World",
+				Cmds: []elk2.Cmd{
+					{Cmd: "echo World"},
 				},
 			},
 		},
@@ -48,7 +56,7 @@ func TestRun(t *testing.T) {
 
 	for taskName := range engine.Elk.Tasks {
 		ctx, cancel := context.WithCancel(ctx)
-		err := engine.Run(ctx, taskName)
+		_, err := engine.Run(ctx, taskName)
 		if err != nil {
 			t.Error(err.Error())
 		}
@@ -61,7 +69,7 @@ func TestRunDoNotExist(t *testing.T) {
 
 	ctx := context.Background()
 
-	err := engine.Run(ctx, "foo")
+	_, err := engine.Run(ctx, "foo")
 	if err == nil {
 		t.Error("Should throw an error because the task do not exist")
 	}
@@ -77,3 +85,660 @@ func TestMapEnvs(t *testing.T) {
 		t.Errorf("The key '%s' should have a value of '%s' but have a value of '%s' instead", key, value, envMap[key])
 	}
 }
+
+type countExecuter struct {
+	mu    sync.Mutex
+	count map[string]int
+}
+
+func (e *countExecuter) Execute(_ context.Context, _ *elk2.Elk, name string) (*Result, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.count[name]++
+	return nil, nil
+}
+
+func TestRunDependencyOnce(t *testing.T) {
+	elk := &elk2.Elk{
+		Tasks: map[string]elk2.Task{
+			"build": {},
+			"test": {
+				Deps: []elk2.Dep{{Name: "build"}},
+			},
+			"lint": {
+				Deps: []elk2.Dep{{Name: "build"}},
+			},
+			"ci": {
+				Deps: []elk2.Dep{{Name: "test"}, {Name: "lint"}},
+			},
+		},
+	}
+
+	executer := &countExecuter{count: make(map[string]int)}
+	e := &Engine{
+		Elk:      elk,
+		Executer: executer,
+	}
+
+	_, err := e.Run(context.Background(), "ci", "test", "lint")
+	if err != nil {
+		t.Error(err)
+	}
+
+	for name := range elk.Tasks {
+		if executer.count[name] != 1 {
+			t.Errorf("The task '%s' should run once but it run %d times", name, executer.count[name])
+		}
+	}
+}
+
+func TestPlan(t *testing.T) {
+	engine := getTestEngine()
+
+	plan, err := engine.Plan("world")
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := []string{"hello", "world"}
+	if !reflect.DeepEqual(plan, expected) {
+		t.Errorf("The plan should be %v but it was %v instead", expected, plan)
+	}
+}
+
+func TestPlanCircularDependency(t *testing.T) {
+	engine := getTestEngine()
+	engine.Elk.Tasks["hello"] = elk2.Task{
+		Deps: []elk2.Dep{{Name: "world"}},
+	}
+
+	_, err := engine.Plan("world")
+	if !errors.Is(err, elk2.ErrCircularDependency) {
+		t.Error("Should throw an error because the task has a circular dependency")
+	}
+
+	expected := "circular dependency: world -> hello -> world"
+	if err != nil && err.Error() != expected {
+		t.Errorf("The error should be '%s' but it was '%s' instead", expected, err.Error())
+	}
+}
+
+type concurrencyExecuter struct {
+	mu      sync.Mutex
+	running int
+	max     int
+}
+
+func (e *concurrencyExecuter) Execute(_ context.Context, _ *elk2.Elk, _ string) (*Result, error) {
+	e.mu.Lock()
+	e.running++
+	if e.running > e.max {
+		e.max = e.running
+	}
+	e.mu.Unlock()
+
+	time.Sleep(10 * time.Millisecond)
+
+	e.mu.Lock()
+	e.running--
+	e.mu.Unlock()
+	return nil, nil
+}
+
+func TestRunConcurrency(t *testing.T) {
+	elk := &elk2.Elk{
+		Concurrency: 2,
+		Tasks: map[string]elk2.Task{
+			"a": {},
+			"b": {},
+			"c": {},
+			"d": {},
+			"all": {
+				Deps: []elk2.Dep{{Name: "a"}, {Name: "b"}, {Name: "c"}, {Name: "d"}},
+			},
+		},
+	}
+
+	executer := &concurrencyExecuter{}
+	e := &Engine{
+		Elk:      elk,
+		Executer: executer,
+	}
+
+	_, err := e.Run(context.Background(), "all")
+	if err != nil {
+		t.Error(err)
+	}
+
+	if executer.max != elk.Concurrency {
+		t.Errorf("The max number of tasks running should be %d but it was %d instead", elk.Concurrency, executer.max)
+	}
+}
+
+func TestRunCollectErrors(t *testing.T) {
+	elk := &elk2.Elk{
+		Tasks: map[string]elk2.Task{
+			"foo": {
+				Cmds: []elk2.Cmd{{Cmd: "exit 1"}},
+			},
+			"bar": {
+				Cmds: []elk2.Cmd{{Cmd: "exit 2"}},
+			},
+			"all": {
+				Deps: []elk2.Dep{{Name: "foo"}, {Name: "bar"}},
+			},
+		},
+	}
+
+	e := &Engine{
+		Elk: elk,
+		Executer: DefaultExecuter{
+			Logger: make(map[string]Logger),
+		},
+	}
+
+	_, err := e.Run(context.Background(), "all")
+	if err == nil {
+		t.Error("Should throw an error because the dependencies fail")
+		return
+	}
+
+	var errs Errors
+	if !errors.As(err, &errs) || len(errs) != 2 {
+		t.Errorf("Should return the error of both dependencies but it returns '%s'", err.Error())
+	}
+}
+
+func TestRunMatrixErrors(t *testing.T) {
+	elk := &elk2.Elk{
+		Tasks: map[string]elk2.Task{
+			"test": {
+				Matrix: map[string][]string{"code": {"1", "2"}},
+				Cmds:   []elk2.Cmd{{Cmd: "exit {{.code}}"}},
+			},
+		},
+	}
+
+	err := elk.ExpandMatrix()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e := &Engine{
+		Elk: elk,
+		Executer: DefaultExecuter{
+			Logger: make(map[string]Logger),
+		},
+	}
+
+	_, err = e.Run(context.Background(), "test")
+	if err == nil {
+		t.Fatal("Should throw an error because the instances fail")
+	}
+
+	for _, expected := range []string{"test[code=1]: exit status 1", "test[code=2]: exit status 2"} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("The error should contain '%s' but it was '%s' instead", expected, err.Error())
+		}
+	}
+}
+
+func TestRunOutputs(t *testing.T) {
+	e := &elk2.Elk{
+		Tasks: map[string]elk2.Task{
+			"build": {
+				Cmds: elk2.NewCmds("echo image_tag=v1.0.0 >> $ELK_OUTPUT"),
+			},
+			"deploy": {
+				Deps: []elk2.Dep{{Name: "build"}},
+				Cmds: elk2.NewCmds(`test "{{.deps.build.outputs.image_tag}}" = "v1.0.0"`),
+			},
+		},
+	}
+
+	err := e.Build()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	var mu sync.Mutex
+	outputs := make(map[string]map[string]string)
+	engine := Engine{
+		Elk: e,
+		Executer: DefaultExecuter{
+			OnOutput: func(task string, values map[string]string) {
+				mu.Lock()
+				defer mu.Unlock()
+				outputs[task] = values
+			},
+		},
+	}
+
+	_, err = engine.Run(context.Background(), "deploy")
+	if err != nil {
+		t.Errorf("The output of the dependency should be available in the command: %v", err)
+	}
+
+	expected := map[string]map[string]string{
+		"build": {"image_tag": "v1.0.0"},
+	}
+
+	if !reflect.DeepEqual(outputs, expected) {
+		t.Errorf("The outputs should be %v but they were %v instead", expected, outputs)
+	}
+}
+
+func TestRunResults(t *testing.T) {
+	e := &elk2.Elk{
+		Tasks: map[string]elk2.Task{
+			"build": {
+				Cmds: elk2.NewCmds("echo version=1.0.0 >> $ELK_OUTPUT"),
+			},
+			"lint": {
+				If:   "false",
+				Cmds: elk2.NewCmds("echo lint"),
+			},
+			"test": {
+				Deps:    []elk2.Dep{{Name: "build"}, {Name: "lint"}},
+				Timeout: 100 * time.Millisecond,
+				Cmds:    elk2.NewCmds("true", "sleep 1"),
+			},
+			"fail": {
+				Cmds: elk2.NewCmds("exit 3"),
+			},
+		},
+	}
+
+	err := e.Build()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	engine := Engine{
+		Elk:      e,
+		Executer: DefaultExecuter{},
+	}
+
+	results, err := engine.Run(context.Background(), "test", "fail")
+	if err == nil {
+		t.Error("The run should fail")
+	}
+
+	if len(results) != 2 {
+		t.Errorf("The run should return %d results but it returns %d instead", 2, len(results))
+		return
+	}
+
+	test := results[0]
+	if test.Status != StatusTimeout {
+		t.Errorf("The status of '%s' should be '%s' but it was '%s' instead", test.Task, StatusTimeout, test.Status)
+	}
+
+	if len(test.Cmds) != 2 || test.Cmds[0].Status != StatusSuccess || test.Cmds[1].Status != StatusCancelled {
+		t.Errorf("The commands of '%s' should be a success and a cancelled command", test.Task)
+	}
+
+	if len(test.Deps) != 2 {
+		t.Errorf("The task '%s' should have %d dependencies but it has %d instead", test.Task, 2, len(test.Deps))
+		return
+	}
+
+	if test.Deps[0].Status != StatusSuccess || test.Deps[0].Outputs["version"] != "1.0.0" {
+		t.Errorf("The dependency '%s' should be a success with outputs", test.Deps[0].Task)
+	}
+
+	if test.Deps[1].Status != StatusSkipped {
+		t.Errorf("The status of '%s' should be '%s' but it was '%s' instead", test.Deps[1].Task, StatusSkipped, test.Deps[1].Status)
+	}
+
+	fail := results[1]
+	if fail.Status != StatusFailed || fail.ExitCode != 3 {
+		t.Errorf("The task '%s' should fail with exit code %d but it was '%s' with %d", fail.Task, 3, fail.Status, fail.ExitCode)
+	}
+}
+
+func TestRunEvents(t *testing.T) {
+	e := &elk2.Elk{
+		Tasks: map[string]elk2.Task{
+			"build": {
+				Cmds: elk2.NewCmds("echo hello; printf world"),
+			},
+			"deploy": {
+				Deps: []elk2.Dep{{Name: "build"}},
+				Cmds: elk2.NewCmds("exit 2"),
+			},
+		},
+	}
+
+	err := e.Build()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	var events []string
+	runIDs := make(map[string]bool)
+
+	engine := Engine{
+		Elk: e,
+		Executer: DefaultExecuter{
+			Logger: map[string]Logger{
+				"build": {
+					StdoutWriter: ioutil.Discard,
+					StderrWriter: ioutil.Discard,
+				},
+			},
+		},
+	}
+
+	engine.Subscribe(ObserverFunc(func(event Event) {
+		runIDs[event.RunID] = true
+
+		description := fmt.Sprintf("%s %s", event.Type, event.Task)
+		switch event.Type {
+		case OutputLine:
+			description += fmt.Sprintf(" %s %s", event.Stream, event.Line)
+		case CmdFinished, TaskFinished, RunFinished:
+			description += fmt.Sprintf(" %s %d", event.Status, event.ExitCode)
+		}
+		events = append(events, description)
+	}))
+
+	_, _ = engine.Run(context.Background(), "deploy")
+
+	expected := []string{
+		"run_started ",
+		"task_started build",
+		"cmd_started build",
+		"output_line build stdout hello",
+		"cmd_finished build success 0",
+		"output_line build stdout world",
+		"task_finished build success 0",
+		"task_started deploy",
+		"cmd_started deploy",
+		"cmd_finished deploy failed 2",
+		"task_finished deploy failed 2",
+		"run_finished  failed 2",
+	}
+
+	if !reflect.DeepEqual(events, expected) {
+		t.Errorf("The events should be %v but they were %v instead", expected, events)
+	}
+
+	if len(runIDs) != 1 {
+		t.Errorf("All the events should have the same run ID but there were %d", len(runIDs))
+	}
+}
+
+func TestRunNonInteractive(t *testing.T) {
+	elk := &elk2.Elk{
+		Tasks: map[string]elk2.Task{
+			"edit": {
+				Interactive: true,
+				Cmds:        []elk2.Cmd{{Cmd: "echo edit"}},
+			},
+			"commit": {
+				Deps: []elk2.Dep{{Name: "edit"}},
+				Cmds: []elk2.Cmd{{Cmd: "echo commit"}},
+			},
+		},
+	}
+
+	e := &Engine{
+		Elk: elk,
+		Executer: DefaultExecuter{
+			Logger: make(map[string]Logger),
+		},
+		NonInteractive: true,
+	}
+
+	_, err := e.Run(context.Background(), "commit")
+
+	var interactiveErr *InteractiveError
+	if !errors.As(err, &interactiveErr) || interactiveErr.Task != "edit" {
+		t.Errorf("Should refuse to run the interactive task 'edit' but it returns '%v'", err)
+	}
+}
+
+func TestRunInteractiveDetached(t *testing.T) {
+	elk := &elk2.Elk{
+		Tasks: map[string]elk2.Task{
+			"edit": {
+				Interactive: true,
+				Cmds:        []elk2.Cmd{{Cmd: "echo edit"}},
+			},
+			"commit": {
+				Deps: []elk2.Dep{{Name: "edit", Detached: true}},
+				Cmds: []elk2.Cmd{{Cmd: "sleep 0.1"}},
+			},
+		},
+	}
+
+	err := elk.Build()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	e := &Engine{
+		Elk: elk,
+		Executer: DefaultExecuter{
+			Logger: make(map[string]Logger),
+		},
+	}
+
+	_, err = e.Run(context.Background(), "commit")
+
+	var interactiveErr *InteractiveError
+	if !errors.As(err, &interactiveErr) || interactiveErr.Task != "edit" {
+		t.Errorf("Should refuse to run the interactive task 'edit' as detached but it returns '%v'", err)
+	}
+}
+
+func TestRunHookTasks(t *testing.T) {
+	dir, err := ioutil.TempDir("", "elk-hooks-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	log := filepath.Join(dir, "run.log")
+	echo := func(text string) []elk2.Cmd {
+		return []elk2.Cmd{{Cmd: fmt.Sprintf("echo %s >> %s", text, log)}}
+	}
+
+	elk := &elk2.Elk{
+		Concurrency: 1,
+		Tasks: map[string]elk2.Task{
+			"setup": {
+				Cmds: echo("setup"),
+			},
+			"notify": {
+				Deps: []elk2.Dep{{Name: "setup"}},
+				Cmds: echo("notify"),
+			},
+			"build": {
+				Deps:  []elk2.Dep{{Name: "setup"}},
+				After: []elk2.Hook{{Task: "notify"}},
+				Cmds:  echo("build"),
+			},
+			"deploy": {
+				Deps:    []elk2.Dep{{Name: "build"}},
+				Finally: []elk2.Hook{{Task: "notify"}},
+				Cmds:    echo("deploy"),
+			},
+		},
+	}
+
+	e := &Engine{
+		Elk: elk,
+		Executer: DefaultExecuter{
+			Logger: make(map[string]Logger),
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err = e.Run(ctx, "deploy")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := ioutil.ReadFile(log)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "setup\nbuild\nnotify\ndeploy\nnotify\n"
+	if string(content) != expected {
+		t.Errorf("The hook task should run after its deps and after each task that uses it, the log should be '%s' but it was '%s' instead", expected, string(content))
+	}
+}
+
+func TestRunHookTaskAfterEachTask(t *testing.T) {
+	dir, err := ioutil.TempDir("", "elk-hooks-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	log := filepath.Join(dir, "run.log")
+	echo := func(text string) []elk2.Cmd {
+		return []elk2.Cmd{{Cmd: fmt.Sprintf("echo %s >> %s", text, log)}}
+	}
+
+	elk := &elk2.Elk{
+		Tasks: map[string]elk2.Task{
+			"unlock": {
+				Cmds: echo("unlock"),
+			},
+			"job1": {
+				Finally: []elk2.Hook{{Task: "unlock"}},
+				Cmds:    echo("job1"),
+			},
+			"job2": {
+				Deps:    []elk2.Dep{{Name: "job1"}},
+				Finally: []elk2.Hook{{Task: "unlock"}},
+				Cmds:    echo("job2"),
+			},
+		},
+	}
+
+	e := &Engine{
+		Elk: elk,
+		Executer: DefaultExecuter{
+			Logger: make(map[string]Logger),
+		},
+	}
+
+	_, err = e.Run(context.Background(), "job2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := ioutil.ReadFile(log)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "job1\nunlock\njob2\nunlock\n"
+	if string(content) != expected {
+		t.Errorf("The finally task should run after each task, the log should be '%s' but it was '%s' instead", expected, string(content))
+	}
+}
+
+func TestRunCircularHookTask(t *testing.T) {
+	elk := &elk2.Elk{
+		Tasks: map[string]elk2.Task{
+			"clean": {
+				After: []elk2.Hook{{Task: "build"}},
+			},
+			"build": {
+				Deps: []elk2.Dep{{Name: "clean"}},
+			},
+		},
+	}
+
+	e := &Engine{
+		Elk:      elk,
+		Executer: &countExecuter{count: make(map[string]int)},
+	}
+
+	_, err := e.Run(context.Background(), "build")
+	if !errors.Is(err, elk2.ErrCircularDependency) {
+		t.Errorf("Should throw an error because the hook runs a task that depends on it but it returns '%v'", err)
+	}
+}
+
+func TestRunUndeclaredVar(t *testing.T) {
+	dir, err := ioutil.TempDir("", "elk-vars-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "build.log")
+	elk := &elk2.Elk{
+		Tasks: map[string]elk2.Task{
+			"build": {
+				Cmds: []elk2.Cmd{{Cmd: fmt.Sprintf("echo build > %s", file)}},
+			},
+			"deploy": {
+				Deps: []elk2.Dep{{Name: "build"}},
+				Cmds: []elk2.Cmd{{Cmd: "echo {{.tag}}"}},
+			},
+		},
+	}
+
+	e := &Engine{
+		Elk: elk,
+		Executer: DefaultExecuter{
+			Logger: make(map[string]Logger),
+		},
+	}
+
+	_, err = e.Run(context.Background(), "deploy")
+	if !errors.Is(err, elk2.ErrUndeclaredVar) {
+		t.Errorf("Should throw an error because the var is not declared but it returns '%v'", err)
+	}
+
+	if _, err := os.Stat(file); !os.IsNotExist(err) {
+		t.Errorf("The dependency should not run when a var is not declared")
+	}
+}
+
+func TestRunSharedDependencyError(t *testing.T) {
+	elk := &elk2.Elk{
+		Tasks: map[string]elk2.Task{
+			"build": {
+				Cmds: []elk2.Cmd{{Cmd: "exit 3"}},
+			},
+			"test": {
+				Deps: []elk2.Dep{{Name: "build"}},
+			},
+			"lint": {
+				Deps: []elk2.Dep{{Name: "build"}},
+			},
+		},
+	}
+
+	e := &Engine{
+		Elk: elk,
+		Executer: DefaultExecuter{
+			Logger: make(map[string]Logger),
+		},
+	}
+
+	_, err := e.Run(context.Background(), "test", "lint")
+	if err == nil {
+		t.Fatal("Should throw an error because the dependency fails")
+	}
+
+	var errs Errors
+	if errors.As(err, &errs) {
+		t.Errorf("The error of the dependency should be reported once but it returns '%s'", err.Error())
+	}
+}
@@ -0,0 +1,146 @@
+package engine
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TaskError is the error returned when a task fails during a run
+type TaskError struct {
+	Task string
+	Err  error
+}
+
+func (e *TaskError) Error() string {
+	switch e.Err.(type) {
+	// The error already has the name of the task that failed
+	case *TaskError, Errors, *TimeoutError, *InteractiveError:
+		return e.Err.Error()
+	}
+
+	return fmt.Sprintf("%s: %v", e.Task, e.Err)
+}
+
+// Unwrap returns the error that made the task fail
+func (e *TaskError) Unwrap() error {
+	return e.Err
+}
+
+// TimeoutError is the error returned when a task or a command runs longer than its timeout
+type TimeoutError struct {
+	Task    string
+	Cmd     string
+	Timeout time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	if len(e.Cmd) > 0 {
+		return fmt.Sprintf("command '%s' from task '%s' timed out after %s", e.Cmd, e.Task, e.Timeout)
+	}
+
+	return fmt.Sprintf("task '%s' timed out after %s", e.Task, e.Timeout)
+}
+
+// PreconditionError is the error returned when a precondition of a task fails
+type PreconditionError struct {
+	Task string
+	Sh   string
+	Msg  string
+}
+
+func (e *PreconditionError) Error() string {
+	if len(e.Msg) > 0 {
+		return e.Msg
+	}
+
+	return fmt.Sprintf("precondition '%s' from task '%s' failed", e.Sh, e.Task)
+}
+
+// InteractiveError is the error returned when an interactive task can not be
+// attached to the terminal, like in detached and server runs
+type InteractiveError struct {
+	Task string
+}
+
+func (e *InteractiveError) Error() string {
+	return fmt.Sprintf("task '%s' is interactive, it can only run attached to a terminal", e.Task)
+}
+
+// Errors groups all the errors that happened during a run
+type Errors []error
+
+func (e Errors) Error() string {
+	var messages []string
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+
+	return strings.Join(messages, "\n")
+}
+
+// collectErrors returns nil if there is no error, the error itself if there is
+// only one and Errors if there are more
+func collectErrors(errs []error) error {
+	var result Errors
+	for _, err := range errs {
+		if err != nil {
+			result = append(result, err)
+		}
+	}
+
+	switch len(result) {
+	case 0:
+		return nil
+	case 1:
+		return result[0]
+	default:
+		return result
+	}
+}
+
+// uniqueErrors removes the errors that only report failures that a previous
+// error already reported, like the failure of a dep shared by many tasks
+func uniqueErrors(errs []error) []error {
+	var result []error
+	reported := make(map[string]bool)
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+
+		unique := false
+		for _, failure := range failures(err) {
+			if !reported[failure] {
+				reported[failure] = true
+				unique = true
+			}
+		}
+
+		if unique {
+			result = append(result, err)
+		}
+	}
+
+	return result
+}
+
+// failures returns the errors of the tasks that failed inside an error, each
+// one with the name of the task that failed
+func failures(err error) []string {
+	switch e := err.(type) {
+	case Errors:
+		var result []string
+		for _, err := range e {
+			result = append(result, failures(err)...)
+		}
+		return result
+	case *TaskError:
+		switch e.Err.(type) {
+		case *TaskError, Errors:
+			return failures(e.Err)
+		}
+	}
+
+	return []string{err.Error()}
+}
@@ -0,0 +1,199 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EventType identifies the moment of the lifecycle of a run that an event describes
+type EventType string
+
+const (
+	// RunStarted is emitted once before the tasks of a run start
+	RunStarted EventType = "run_started"
+
+	// RunFinished is emitted once after all the tasks of a run finished
+	RunFinished EventType = "run_finished"
+
+	// TaskStarted is emitted when a task starts after its dependencies finished
+	TaskStarted EventType = "task_started"
+
+	// TaskFinished is emitted when a task finished, even if it did not start
+	// because one of its dependencies failed
+	TaskFinished EventType = "task_finished"
+
+	// CmdStarted is emitted before each attempt of a command
+	CmdStarted EventType = "cmd_started"
+
+	// CmdFinished is emitted after each attempt of a command
+	CmdFinished EventType = "cmd_finished"
+
+	// OutputLine is emitted for each line that a task writes to stdout or stderr
+	OutputLine EventType = "output_line"
+)
+
+// Stream is the output where a line was written
+type Stream string
+
+const (
+	// Stdout is the standard output of a task
+	Stdout Stream = "stdout"
+
+	// Stderr is the standard error of a task
+	Stderr Stream = "stderr"
+)
+
+// Event describes something that happened during a run
+type Event struct {
+	Type  EventType
+	RunID string
+	Task  string
+	Time  time.Time
+
+	// Cmd and Attempt are set for command events
+	Cmd     string
+	Attempt int
+
+	// Status and ExitCode are set for finished events
+	Status   Status
+	ExitCode int
+
+	// Line and Stream are set for output events, the line do not include the new line
+	Line   string
+	Stream Stream
+}
+
+// Observer receives the events of the runs of an engine
+type Observer interface {
+	OnEvent(Event)
+}
+
+// ObserverFunc is a function that can be used as an Observer
+type ObserverFunc func(Event)
+
+// OnEvent calls the function with the event
+func (f ObserverFunc) OnEvent(event Event) {
+	f(event)
+}
+
+type eventsKey struct{}
+
+// events sends the events of a run to the observers, events are delivered in
+// order and one at a time
+type events struct {
+	mu        sync.Mutex
+	runID     string
+	observers []Observer
+}
+
+func newEvents(observers []Observer) *events {
+	return &events{
+		runID:     newRunID(),
+		observers: observers,
+	}
+}
+
+func withEvents(ctx context.Context, e *events) context.Context {
+	return context.WithValue(ctx, eventsKey{}, e)
+}
+
+func getEvents(ctx context.Context) *events {
+	e, _ := ctx.Value(eventsKey{}).(*events)
+	return e
+}
+
+// emit sends an event to the observers, it does nothing if the run do not have observers
+func (e *events) emit(event Event) {
+	if e == nil || len(e.observers) == 0 {
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	event.RunID = e.runID
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+
+	for _, observer := range e.observers {
+		observer.OnEvent(event)
+	}
+}
+
+// logger returns a logger that also emits an event for each line written by
+// a task and a function that emits the last line if it do not end with a new line
+func (e *events) logger(task string, logger Logger) (Logger, func()) {
+	if e == nil || len(e.observers) == 0 {
+		return logger, func() {}
+	}
+
+	stdout := &lineWriter{events: e, task: task, stream: Stdout, w: logger.StdoutWriter}
+	stderr := &lineWriter{events: e, task: task, stream: Stderr, w: logger.StderrWriter}
+
+	logger.StdoutWriter = stdout
+	logger.StderrWriter = stderr
+
+	return logger, func() {
+		stdout.flush()
+		stderr.flush()
+	}
+}
+
+// lineWriter writes to another writer and emits an event for each complete line
+type lineWriter struct {
+	events *events
+	task   string
+	stream Stream
+	w      io.Writer
+
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (l *lineWriter) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	l.buf.Write(p)
+	for {
+		i := bytes.IndexByte(l.buf.Bytes(), '\n')
+		if i < 0 {
+			break
+		}
+
+		l.emit(string(l.buf.Next(i + 1)[:i]))
+	}
+	l.mu.Unlock()
+
+	return l.w.Write(p)
+}
+
+func (l *lineWriter) flush() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.buf.Len() > 0 {
+		l.emit(l.buf.String())
+		l.buf.Reset()
+	}
+}
+
+func (l *lineWriter) emit(line string) {
+	l.events.emit(Event{
+		Type:   OutputLine,
+		Task:   l.task,
+		Line:   strings.TrimSuffix(line, "\r"),
+		Stream: l.stream,
+	})
+}
+
+func newRunID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -3,123 +3,453 @@ package engine
 import (
 	"context"
 	"fmt"
-	"io"
+	"io/ioutil"
 	"os"
-	"strings"
+	"time"
 
 	"github.com/jjzcru/elk/pkg/primitives/ox"
+	"github.com/jjzcru/elk/pkg/shell"
 
-	"mvdan.cc/sh/expand"
 	"mvdan.cc/sh/interp"
-	"mvdan.cc/sh/syntax"
 )
 
-// Executer runs a task and returns a PID and an error
+// Executer runs a task and returns its result and an error
 type Executer interface {
-	Execute(context.Context, *ox.Elk, string) (int, error)
+	Execute(context.Context, *ox.Elk, string) (*Result, error)
 }
 
 // DefaultExecuter Execute task with a POSIX emulator
 type DefaultExecuter struct {
 	Logger map[string]Logger
+
+	// OnRetry is called with the attempt number before a command from a task runs again
+	OnRetry func(task string, attempt int)
+
+	// OnSkip is called when a task does not run because its if condition is
+	// false or because it is up to date
+	OnSkip func(task string)
+
+	// OnOutput is called with the values a task wrote to the file in ELK_OUTPUT
+	OnOutput func(task string, outputs map[string]string)
+
+	// Force runs the tasks even if they are up to date
+	Force bool
 }
 
-// Execute task and returns a PID
-func (e DefaultExecuter) Execute(ctx context.Context, elk *ox.Elk, name string) (int, error) {
-	ctx, cancel := context.WithCancel(ctx)
-	defer cancel()
+// Execute the commands of a task and returns its result, dependencies are resolved by the engine
+func (e DefaultExecuter) Execute(ctx context.Context, elk *ox.Elk, name string) (*Result, error) {
+	logger, exists := e.Logger[name]
+	if !exists {
+		logger = DefaultLogger()
+	}
+
+	if getOutputs(ctx) == nil {
+		ctx = withOutputs(ctx, newOutputs())
+	}
 
-	pid := os.Getpid()
+	logger, flush := getEvents(ctx).logger(name, logger)
+	defer flush()
 
+	return e.execute(ctx, elk, name, logger)
+}
+
+// execute runs a task and returns its result
+func (e DefaultExecuter) execute(ctx context.Context, elk *ox.Elk, name string, logger Logger) (*Result, error) {
+	result := &Result{
+		Task:    name,
+		StartAt: time.Now(),
+	}
+
+	err := e.executeTask(ctx, elk, name, logger, result)
+	if result.Status == StatusSkipped {
+		result.EndAt = time.Now()
+		return result, nil
+	}
+
+	result.finish(ctx, err)
+	return result, err
+}
+
+// executeTask runs the hooks and the commands of a task, the on_failure and
+// finally hooks run even if the context was cancelled
+func (e DefaultExecuter) executeTask(ctx context.Context, elk *ox.Elk, name string, logger Logger, result *Result) error {
 	task, err := elk.GetTask(name)
 	if err != nil {
-		return pid, err
+		return err
 	}
 
-	var detachedDeps []ox.Dep
-	var deps []ox.Dep
-
-	for _, dep := range task.Deps {
-		if dep.Detached {
-			detachedDeps = append(detachedDeps, dep)
-		} else {
-			deps = append(deps, dep)
+	if len(task.Dir) == 0 {
+		task.Dir, err = os.Getwd()
+		if err != nil {
+			return err
 		}
 	}
 
-	if len(detachedDeps) > 0 {
-		for _, dep := range detachedDeps {
-			go func(name string) {
-				_, _ = e.Execute(ctx, elk, name)
-			}(dep.Name)
+	ok, err := e.checkCondition(ctx, name, task, logger)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		e.skip(name, result)
+		return nil
+	}
+
+	err = e.checkPreconditions(ctx, name, task, logger)
+	if err != nil {
+		return err
+	}
+
+	store := getOutputs(ctx)
+	deps := store.deps(task)
+
+	if !e.Force {
+		upToDate, values, err := isUpToDate(name, task, deps)
+		if err != nil {
+			return err
+		}
+
+		if upToDate {
+			e.setOutputs(store, name, values, result)
+			e.skip(name, result)
+			return nil
 		}
 	}
 
-	if len(deps) > 0 {
-		for _, dep := range deps {
-			_, err := e.Execute(ctx, elk, dep.Name)
-			if err != nil && !dep.IgnoreError {
-				return pid, err
-			}
+	outputPath, err := createOutputFile()
+	if err != nil {
+		return err
+	}
+	defer os.Remove(outputPath)
+
+	env := make(map[string]string)
+	for k, v := range task.Env {
+		env[k] = v
+	}
+	env[OutputEnv] = outputPath
+	task.Env = env
+
+	parentCtx := ctx
+	if task.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, task.Timeout)
+		defer cancel()
+	}
+
+	err = e.runHooks(ctx, elk, name, task, task.Before, logger, false)
+	if err == nil {
+		err = e.runCmds(ctx, name, task, logger, result)
+	}
+
+	if err == nil {
+		err = e.runHooks(ctx, elk, name, task, task.After, logger, false)
+	}
+
+	if err != nil && ctx.Err() == context.DeadlineExceeded && parentCtx.Err() == nil {
+		err = &TimeoutError{
+			Task:    name,
+			Timeout: task.Timeout,
 		}
 	}
 
-	if len(task.Dir) == 0 {
-		task.Dir, err = os.Getwd()
+	hookCtx := parentCtx
+	if hookCtx.Err() != nil {
+		hookCtx = valuesContext{parentCtx}
+	}
+
+	errs := []error{err}
+	if err != nil {
+		errs = append(errs, e.runHooks(hookCtx, elk, name, task, task.OnFailure, logger, true))
+	}
+
+	errs = append(errs, e.runHooks(hookCtx, elk, name, task, task.Finally, logger, true))
+
+	values, err := readOutputs(name, outputPath)
+	if err != nil {
+		errs = append(errs, err)
+	} else {
+		e.setOutputs(store, name, values, result)
+	}
+
+	err = collectErrors(errs)
+	if err != nil {
+		return err
+	}
+
+	return saveFingerprint(name, task, deps, values)
+}
+
+// skip marks a task as skipped
+func (e DefaultExecuter) skip(name string, result *Result) {
+	result.Status = StatusSkipped
+	if e.OnSkip != nil {
+		e.OnSkip(name)
+	}
+}
+
+// setOutputs stores the outputs of a task so its dependents can use them
+func (e DefaultExecuter) setOutputs(store *outputs, name string, values map[string]string, result *Result) {
+	result.Outputs = values
+	store.set(name, values)
+	if e.OnOutput != nil && len(values) > 0 {
+		e.OnOutput(name, values)
+	}
+}
+
+// render returns a command after applying the vars of a task and the outputs
+// of its dependencies
+func (e DefaultExecuter) render(ctx context.Context, task *ox.Task, cmd string) (string, error) {
+	return ox.GetCmdFromTask(task, getOutputs(ctx).deps(task), cmd)
+}
+
+// checkCondition returns if the if condition of a task is true, a condition is
+// true when its command exits with status 0
+func (e DefaultExecuter) checkCondition(ctx context.Context, name string, task *ox.Task, logger Logger) (bool, error) {
+	if len(task.If) == 0 {
+		return true, nil
+	}
+
+	return e.check(ctx, name, task, task.If, logger)
+}
+
+// checkPreconditions returns an error with the message of the first
+// precondition of a task that fails
+func (e DefaultExecuter) checkPreconditions(ctx context.Context, name string, task *ox.Task, logger Logger) error {
+	for _, precondition := range task.Preconditions {
+		ok, err := e.check(ctx, name, task, precondition.Sh, logger)
 		if err != nil {
-			return pid, err
+			return err
+		}
+
+		if !ok {
+			return &PreconditionError{
+				Task: name,
+				Sh:   precondition.Sh,
+				Msg:  precondition.Msg,
+			}
 		}
 	}
 
-	var stdinReader io.Reader
-	var stdoutWriter io.Writer
-	var stderrWriter io.Writer
+	return nil
+}
 
-	logger, exists := e.Logger[name]
+// check runs a shell check and returns if it exits with status 0, the output
+// of the check is discarded
+func (e DefaultExecuter) check(ctx context.Context, name string, task *ox.Task, check string, logger Logger) (bool, error) {
+	cmd, err := e.render(ctx, task, check)
+	if err != nil {
+		return false, err
+	}
 
-	if !exists {
-		stdinReader = os.Stdin
-		stdoutWriter = os.Stdout
-		stderrWriter = os.Stderr
-	} else {
-		stdinReader = logger.StdinReader
-		stdoutWriter = logger.StdoutWriter
-		stderrWriter = logger.StderrWriter
+	logger.StdoutWriter = ioutil.Discard
+	err = e.runCmd(ctx, name, task, ox.Cmd{Cmd: check}, cmd, logger)
+	if err == nil {
+		return true, nil
+	}
+
+	if _, ok := getExitCode(err); ok && ctx.Err() == nil {
+		return false, nil
 	}
 
+	return false, err
+}
+
+// runCmds runs the commands of a task and adds the result of each one to the
+// result of the task
+func (e DefaultExecuter) runCmds(ctx context.Context, name string, task *ox.Task, logger Logger, result *Result) error {
 	for _, command := range task.Cmds {
-		command, err := ox.GetCmdFromVars(task.Vars, command)
-		if err != nil {
-			return pid, err
+		retry := command.GetRetry(task)
+
+		cmdResult := &CmdResult{
+			Cmd:     command.Cmd,
+			StartAt: time.Now(),
 		}
+		result.Cmds = append(result.Cmds, cmdResult)
 
-		p, err := syntax.NewParser().Parse(strings.NewReader(command), "")
+		cmd, err := e.render(ctx, task, command.Cmd)
 		if err != nil {
-			return pid, err
+			cmdResult.finish(ctx, err)
+			return err
 		}
 
-		envs := getEnvs(task.Env)
+		for attempt := 1; ; attempt++ {
+			cmdResult.Attempts = attempt
+			if attempt > result.Attempts {
+				result.Attempts = attempt
+			}
 
-		r, err := interp.New(
-			interp.Dir(task.Dir),
+			getEvents(ctx).emit(Event{
+				Type:    CmdStarted,
+				Task:    name,
+				Cmd:     command.Cmd,
+				Attempt: attempt,
+			})
 
-			interp.Env(expand.ListEnviron(envs...)),
+			err = e.runCmd(ctx, name, task, command, cmd, logger)
 
-			interp.Module(interp.DefaultExec),
-			interp.Module(interp.OpenDevImpls(interp.DefaultOpen)),
+			status, exitCode := GetStatus(ctx, err)
+			getEvents(ctx).emit(Event{
+				Type:     CmdFinished,
+				Task:     name,
+				Cmd:      command.Cmd,
+				Attempt:  attempt,
+				Status:   status,
+				ExitCode: exitCode,
+			})
 
-			interp.StdIO(stdinReader, stdoutWriter, stderrWriter),
-		)
+			if err == nil || ctx.Err() != nil || attempt >= retry.GetAttempts() {
+				break
+			}
+
+			if !shouldRetry(retry, err) {
+				break
+			}
+
+			delay := retry.GetDelay(attempt + 1)
+			_, _ = fmt.Fprintf(logger.StderrWriter, "%s, retrying attempt %d/%d in %s\n", err.Error(), attempt+1, retry.GetAttempts(), delay)
+
+			if e.OnRetry != nil {
+				e.OnRetry(name, attempt+1)
+			}
+
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+			}
+		}
+
+		cmdResult.finish(ctx, err)
+
+		if err != nil && (!task.IgnoreError || ctx.Err() != nil) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// runHooks runs a list of hooks, if keepRunning is set all the hooks run even
+// if one of them fails
+func (e DefaultExecuter) runHooks(ctx context.Context, elk *ox.Elk, name string, task *ox.Task, hooks []ox.Hook, logger Logger, keepRunning bool) error {
+	var errs []error
+	for _, hook := range hooks {
+		var err error
+		if len(hook.Task) > 0 {
+			err = e.runHookTask(ctx, elk, hook.Task, logger)
+		} else {
+			var cmd string
+			cmd, err = e.render(ctx, task, hook.Cmd)
+			if err == nil {
+				err = e.runCmd(ctx, name, task, ox.Cmd{Cmd: hook.Cmd}, cmd, logger)
+			}
+		}
 
 		if err != nil {
-			return pid, err
+			if !keepRunning {
+				return err
+			}
+			errs = append(errs, err)
 		}
-		err = r.Run(ctx, p)
-		if err != nil && !task.IgnoreError {
-			return pid, err
+	}
+
+	return collectErrors(errs)
+}
+
+// runHookTask runs a task used as a hook with its deps through the scheduler
+// of the run, without a run it only runs the task with the logger of the hook
+func (e DefaultExecuter) runHookTask(ctx context.Context, elk *ox.Elk, task string, logger Logger) error {
+	s := getScheduler(ctx)
+	if s == nil {
+		_, err := e.execute(ctx, elk, task, logger)
+		return err
+	}
+
+	_, err := s.runHook(ctx, task)
+	if err != nil {
+		return &TaskError{Task: task, Err: err}
+	}
+
+	return nil
+}
+
+// valuesContext keeps the values of a context without its cancellation, the
+// hooks that run after a task was cancelled use it
+type valuesContext struct {
+	context.Context
+}
+
+func (valuesContext) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (valuesContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (valuesContext) Err() error {
+	return nil
+}
+
+func (e DefaultExecuter) runCmd(ctx context.Context, name string, task *ox.Task, command ox.Cmd, cmd string, logger Logger) error {
+	limits, err := task.Limits.GetShellLimits()
+	if err != nil {
+		return err
+	}
+
+	c := shell.Command{
+		Shell:  command.GetShell(task),
+		Cmd:    cmd,
+		Dir:    task.Dir,
+		Env:    getEnvs(task.Env),
+		Stdin:  logger.StdinReader,
+		Stdout: logger.StdoutWriter,
+		Stderr: logger.StderrWriter,
+		TTY:    task.Interactive,
+
+		GracePeriod: task.GracePeriod,
+		Limits:      limits,
+	}
+
+	cmdCtx := ctx
+	if command.Timeout > 0 {
+		var cancel context.CancelFunc
+		cmdCtx, cancel = context.WithTimeout(ctx, command.Timeout)
+		defer cancel()
+	}
+
+	err = shell.Run(cmdCtx, c)
+	if err != nil && cmdCtx.Err() == context.DeadlineExceeded && ctx.Err() == nil {
+		return &TimeoutError{
+			Task:    name,
+			Cmd:     command.Cmd,
+			Timeout: command.Timeout,
 		}
 	}
-	return pid, nil
+
+	return err
+}
+
+// shouldRetry returns if a command that failed should run again, a command that
+// timed out only runs again when the policy do not filter by exit codes
+func shouldRetry(retry *ox.Retry, err error) bool {
+	if _, ok := err.(*TimeoutError); ok {
+		return retry != nil && len(retry.ExitCodes) == 0
+	}
+
+	exitCode, ok := getExitCode(err)
+	return ok && retry.ShouldRetry(exitCode)
+}
+
+// getExitCode returns the exit code from an error returned by the interpreter
+func getExitCode(err error) (int, bool) {
+	switch status := err.(type) {
+	case interp.ExitStatus:
+		return int(status), true
+	case interp.ShellExitStatus:
+		return int(status), true
+	default:
+		return 0, false
+	}
 }
 
 func getEnvs(envMap map[string]string) []string {
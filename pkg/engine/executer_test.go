@@ -1,8 +1,14 @@
 package engine
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"os"
 	"testing"
+	"time"
 
 	"github.com/jjzcru/elk/pkg/primitives/ox"
 )
@@ -24,8 +30,8 @@ func TestDefaultExecuterExecute(t *testing.T) {
 				Env: map[string]string{
 					"FOO": "BAR",
 				},
-				Cmds: []string{
-					"echo $FOO",
+				Cmds: []ox.Cmd{
+					{Cmd: "echo $FOO"},
 				},
 			},
 			"hello": {
@@ -33,8 +39,8 @@ func TestDefaultExecuterExecute(t *testing.T) {
 				Env: map[string]string{
 					"FOO": "Bar",
 				},
-				Cmds: []string{
-					"echo $FOO",
+				Cmds: []ox.Cmd{
+					{Cmd: "echo $FOO"},
 				},
 			},
 			"foo": {
@@ -42,8 +48,8 @@ func TestDefaultExecuterExecute(t *testing.T) {
 				Env: map[string]string{
 					"FOO": "Bar",
 				},
-				Cmds: []string{
-					"echo $FOO",
+				Cmds: []ox.Cmd{
+					{Cmd: "echo $FOO"},
 				},
 			},
 		},
@@ -77,8 +83,8 @@ func TestDefaultExecuterExecuteTaskNotExist(t *testing.T) {
 				Env: map[string]string{
 					"FOO": "BAR",
 				},
-				Cmds: []string{
-					"echo $FOO",
+				Cmds: []ox.Cmd{
+					{Cmd: "echo $FOO"},
 				},
 			},
 			"hello": {
@@ -86,8 +92,8 @@ func TestDefaultExecuterExecuteTaskNotExist(t *testing.T) {
 				Env: map[string]string{
 					"FOO": "Bar",
 				},
-				Cmds: []string{
-					"echo $FOO",
+				Cmds: []ox.Cmd{
+					{Cmd: "echo $FOO"},
 				},
 			},
 			"foo": {
@@ -95,8 +101,8 @@ func TestDefaultExecuterExecuteTaskNotExist(t *testing.T) {
 				Env: map[string]string{
 					"FOO": "Bar",
 				},
-				Cmds: []string{
-					"echo $FOO",
+				Cmds: []ox.Cmd{
+					{Cmd: "echo $FOO"},
 				},
 			},
 		},
@@ -112,3 +118,256 @@ func TestDefaultExecuterExecuteTaskNotExist(t *testing.T) {
 	}
 
 }
+
+func TestDefaultExecuterExecuteRetry(t *testing.T) {
+	marker := fmt.Sprintf("./retry_%d", rand.Intn(1000))
+	defer os.Remove(marker)
+
+	e := ox.Elk{
+		Tasks: map[string]ox.Task{
+			"flaky": {
+				Retry: &ox.Retry{
+					Attempts: 3,
+				},
+				Cmds: []ox.Cmd{
+					{Cmd: fmt.Sprintf("[ -f %s ] || { echo > %s; exit 1; }", marker, marker)},
+				},
+			},
+		},
+	}
+
+	var attempts int
+	executer := DefaultExecuter{
+		Logger: map[string]Logger{
+			"flaky": {
+				StdoutWriter: ioutil.Discard,
+				StderrWriter: ioutil.Discard,
+			},
+		},
+		OnRetry: func(task string, attempt int) {
+			attempts = attempt
+		},
+	}
+
+	_, err := executer.Execute(context.Background(), &e, "flaky")
+	if err != nil {
+		t.Error(err)
+	}
+
+	if attempts != 2 {
+		t.Errorf("The task should succeed on attempt %d but it was %d instead", 2, attempts)
+	}
+}
+
+func TestDefaultExecuterExecuteTimeout(t *testing.T) {
+	e := ox.Elk{
+		Tasks: map[string]ox.Task{
+			"task": {
+				Timeout: 50 * time.Millisecond,
+				Cmds:    ox.NewCmds("sleep 5"),
+			},
+			"cmd": {
+				Cmds: []ox.Cmd{
+					{Cmd: "sleep 5", Timeout: 50 * time.Millisecond},
+				},
+			},
+		},
+	}
+
+	err := e.Build()
+	if err != nil {
+		t.Error(err)
+	}
+
+	executer := DefaultExecuter{
+		Logger: make(map[string]Logger),
+	}
+
+	for name := range e.Tasks {
+		_, err := executer.Execute(context.Background(), &e, name)
+		timeoutErr, ok := err.(*TimeoutError)
+		if !ok {
+			t.Errorf("The task '%s' should return a timeout error but it returns '%v'", name, err)
+			continue
+		}
+
+		if name == "cmd" && len(timeoutErr.Cmd) == 0 {
+			t.Error("The timeout error should include the command that timed out")
+		}
+	}
+}
+
+func TestDefaultExecuterExecuteHooks(t *testing.T) {
+	e := ox.Elk{
+		Tasks: map[string]ox.Task{
+			"success": {
+				Before:    []ox.Hook{{Cmd: "echo before"}},
+				After:     []ox.Hook{{Task: "cleanup"}},
+				OnFailure: []ox.Hook{{Cmd: "echo on_failure"}},
+				Finally:   []ox.Hook{{Cmd: "echo finally"}},
+				Cmds:      ox.NewCmds("echo cmd"),
+			},
+			"failure": {
+				Before:    []ox.Hook{{Cmd: "echo before"}},
+				After:     []ox.Hook{{Task: "cleanup"}},
+				OnFailure: []ox.Hook{{Cmd: "echo on_failure"}},
+				Finally:   []ox.Hook{{Cmd: "echo finally"}},
+				Cmds:      ox.NewCmds("exit 1"),
+			},
+			"cleanup": {
+				Cmds: ox.NewCmds("echo cleanup"),
+			},
+		},
+	}
+
+	expected := map[string]string{
+		"success": "before\ncmd\ncleanup\nfinally\n",
+		"failure": "before\non_failure\nfinally\n",
+	}
+
+	for name, output := range expected {
+		var out bytes.Buffer
+		executer := DefaultExecuter{
+			Logger: map[string]Logger{
+				name: {
+					StdoutWriter: &out,
+					StderrWriter: ioutil.Discard,
+				},
+			},
+		}
+
+		_, err := executer.Execute(context.Background(), &e, name)
+		if (err != nil) != (name == "failure") {
+			t.Errorf("The task '%s' returns an unexpected error: %v", name, err)
+		}
+
+		if out.String() != output {
+			t.Errorf("The output of task '%s' should be '%s' but it was '%s' instead", name, output, out.String())
+		}
+	}
+}
+
+func TestDefaultExecuterExecuteFinallyOnCancel(t *testing.T) {
+	e := ox.Elk{
+		Tasks: map[string]ox.Task{
+			"hello": {
+				Finally: []ox.Hook{{Cmd: "echo finally"}},
+				Cmds:    ox.NewCmds("echo hello"),
+			},
+		},
+	}
+
+	var out bytes.Buffer
+	executer := DefaultExecuter{
+		Logger: map[string]Logger{
+			"hello": {
+				StdoutWriter: &out,
+				StderrWriter: ioutil.Discard,
+			},
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _ = executer.Execute(ctx, &e, "hello")
+	if out.String() != "finally\n" {
+		t.Errorf("The finally hook should run when the context is cancelled, the output was '%s'", out.String())
+	}
+}
+
+func TestDefaultExecuterExecuteCondition(t *testing.T) {
+	e := ox.Elk{
+		Tasks: map[string]ox.Task{
+			"prod": {
+				Vars:    map[string]string{"env": "prod"},
+				If:      `{{eq .env "prod"}}`,
+				Finally: []ox.Hook{{Cmd: "echo finally"}},
+				Cmds:    ox.NewCmds("echo deploy"),
+			},
+			"dev": {
+				Vars:    map[string]string{"env": "dev"},
+				If:      `{{eq .env "prod"}}`,
+				Finally: []ox.Hook{{Cmd: "echo finally"}},
+				Cmds:    ox.NewCmds("echo deploy"),
+			},
+			"shell": {
+				If:   "echo check && [ -z \"$HOME\" ]",
+				Cmds: ox.NewCmds("echo deploy"),
+			},
+		},
+	}
+
+	expected := map[string]string{
+		"prod":  "deploy\nfinally\n",
+		"dev":   "",
+		"shell": "",
+	}
+
+	for name, output := range expected {
+		var out bytes.Buffer
+		var skipped []string
+		executer := DefaultExecuter{
+			Logger: map[string]Logger{
+				name: {
+					StdoutWriter: &out,
+					StderrWriter: ioutil.Discard,
+				},
+			},
+			OnSkip: func(task string) {
+				skipped = append(skipped, task)
+			},
+		}
+
+		_, err := executer.Execute(context.Background(), &e, name)
+		if err != nil {
+			t.Errorf("The task '%s' returns an unexpected error: %v", name, err)
+		}
+
+		if out.String() != output {
+			t.Errorf("The output of task '%s' should be '%s' but it was '%s' instead", name, output, out.String())
+		}
+
+		if (len(skipped) == 1) != (name != "prod") {
+			t.Errorf("The task '%s' was skipped %d times", name, len(skipped))
+		}
+	}
+}
+
+func TestDefaultExecuterExecutePreconditions(t *testing.T) {
+	e := ox.Elk{
+		Tasks: map[string]ox.Task{
+			"deploy": {
+				Preconditions: []ox.Precondition{
+					{Sh: "true"},
+					{Sh: "test -f ./not-exist.yml", Msg: "The file 'not-exist.yml' is required"},
+				},
+				Cmds: ox.NewCmds("echo deploy"),
+			},
+		},
+	}
+
+	var out bytes.Buffer
+	executer := DefaultExecuter{
+		Logger: map[string]Logger{
+			"deploy": {
+				StdoutWriter: &out,
+				StderrWriter: ioutil.Discard,
+			},
+		},
+	}
+
+	_, err := executer.Execute(context.Background(), &e, "deploy")
+	if err == nil {
+		t.Error("The task should fail because a precondition fails")
+		return
+	}
+
+	if err.Error() != "The file 'not-exist.yml' is required" {
+		t.Errorf("The error should be the message of the precondition but it was '%s' instead", err.Error())
+	}
+
+	if out.Len() > 0 {
+		t.Errorf("The commands should not run but the output was '%s'", out.String())
+	}
+}
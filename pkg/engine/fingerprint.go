@@ -0,0 +1,217 @@
+package engine
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/jjzcru/elk/pkg/primitives/ox"
+)
+
+// fingerprintDir is the directory, inside the dir of a task, where the
+// fingerprints of the tasks that declare generates are stored
+const fingerprintDir = ".elk"
+
+// isUpToDate returns true if the sources, outputs, commands and env of a task
+// are the same as the last time it ran successfully, it also returns the
+// values the task wrote to ELK_OUTPUT that time
+func isUpToDate(name string, task *ox.Task, deps map[string]map[string]string) (bool, map[string]string, error) {
+	if len(task.Generates) == 0 {
+		return false, nil, nil
+	}
+
+	sum, ok, err := fingerprint(task, deps)
+	if err != nil || !ok {
+		return false, nil, err
+	}
+
+	content, err := ioutil.ReadFile(getFingerprintPath(name, task))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil, nil
+		}
+		return false, nil, err
+	}
+
+	// The first line is the fingerprint and the rest are the values from ELK_OUTPUT
+	parts := strings.SplitN(string(content), "\n", 2)
+	if parts[0] != sum {
+		return false, nil, nil
+	}
+
+	values := make(map[string]string)
+	if len(parts) > 1 {
+		values, err = parseOutputs(name, strings.NewReader(parts[1]))
+		if err != nil {
+			return false, nil, err
+		}
+	}
+
+	return true, values, nil
+}
+
+// saveFingerprint stores the fingerprint of a task that ran successfully, the
+// fingerprint is not stored if the task did not generate all its outputs
+func saveFingerprint(name string, task *ox.Task, deps map[string]map[string]string, values map[string]string) error {
+	if len(task.Generates) == 0 {
+		return nil
+	}
+
+	sum, ok, err := fingerprint(task, deps)
+	if err != nil || !ok {
+		return err
+	}
+
+	path := getFingerprintPath(name, task)
+	err = os.MkdirAll(filepath.Dir(path), os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, []byte(sum+"\n"+formatOutputs(values)), 0644)
+}
+
+func getFingerprintPath(name string, task *ox.Task) string {
+	return filepath.Join(task.Dir, fingerprintDir, "fingerprints", url.PathEscape(name))
+}
+
+// fingerprint returns a hash of the rendered commands, the env, the content of
+// the sources and the content of the outputs of a task, it returns false if
+// one of the outputs does not exist
+func fingerprint(task *ox.Task, deps map[string]map[string]string) (string, bool, error) {
+	h := sha256.New()
+
+	for _, command := range task.Cmds {
+		cmd, err := ox.GetCmdFromTask(task, deps, command.Cmd)
+		if err != nil {
+			return "", false, err
+		}
+		_, _ = fmt.Fprintf(h, "cmd %q\n", cmd)
+	}
+
+	var envs []string
+	for env, value := range task.Env {
+		// Only the env that is not inherited from the system is part of the fingerprint
+		if env == OutputEnv {
+			continue
+		}
+
+		if osValue, ok := os.LookupEnv(env); ok && osValue == value {
+			continue
+		}
+		envs = append(envs, fmt.Sprintf("%s=%s", env, value))
+	}
+	sort.Strings(envs)
+
+	for _, env := range envs {
+		_, _ = fmt.Fprintf(h, "env %q\n", env)
+	}
+
+	sources, err := getSources(task)
+	if err != nil {
+		return "", false, err
+	}
+
+	err = hashFiles(h, "source", task.Dir, sources)
+	if err != nil {
+		return "", false, err
+	}
+
+	for _, pattern := range task.Generates {
+		outputs, err := filepath.Glob(filepath.Join(task.Dir, pattern))
+		if err != nil {
+			return "", false, err
+		}
+
+		if len(outputs) == 0 {
+			return "", false, nil
+		}
+
+		err = hashFiles(h, "generates", task.Dir, outputs)
+		if err != nil {
+			return "", false, err
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), true, nil
+}
+
+// getSources returns the files inside the dir of a task that match its sources
+func getSources(task *ox.Task) ([]string, error) {
+	if len(task.Sources) == 0 {
+		return nil, nil
+	}
+
+	re, err := regexp.Compile(task.Sources)
+	if err != nil {
+		return nil, err
+	}
+
+	cacheDir := filepath.Join(task.Dir, fingerprintDir)
+
+	var files []string
+	err = filepath.Walk(task.Dir, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if fi.IsDir() {
+			if path == cacheDir {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if re.MatchString(path) {
+			files = append(files, path)
+		}
+
+		return nil
+	})
+
+	return files, err
+}
+
+// hashFiles writes the relative path and the content of each file to the
+// hash, directories are walked recursively
+func hashFiles(h hash.Hash, kind string, dir string, paths []string) error {
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		err := filepath.Walk(path, func(path string, fi os.FileInfo, err error) error {
+			if err != nil || fi.IsDir() {
+				return err
+			}
+
+			rel, err := filepath.Rel(dir, path)
+			if err != nil {
+				return err
+			}
+
+			f, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+
+			_, _ = fmt.Fprintf(h, "%s %q %d\n", kind, filepath.ToSlash(rel), fi.Size())
+			_, err = io.Copy(h, f)
+			return err
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jjzcru/elk/pkg/primitives/ox"
+)
+
+func TestDefaultExecuterExecuteUpToDate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "elk")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	source := filepath.Join(dir, "main.txt")
+	err = ioutil.WriteFile(source, []byte("hello"), 0644)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	e := ox.Elk{
+		Tasks: map[string]ox.Task{
+			"build": {
+				Dir:       dir,
+				Sources:   `\.txt$`,
+				Generates: []string{"*.out"},
+				Cmds:      ox.NewCmds("cat main.txt > main.out", "echo build"),
+			},
+		},
+	}
+
+	err = e.Build()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	run := func(force bool) (string, bool) {
+		var out bytes.Buffer
+		skipped := false
+		executer := DefaultExecuter{
+			Logger: map[string]Logger{
+				"build": {
+					StdoutWriter: &out,
+					StderrWriter: ioutil.Discard,
+				},
+			},
+			OnSkip: func(task string) {
+				skipped = true
+			},
+			Force: force,
+		}
+
+		_, err := executer.Execute(context.Background(), &e, "build")
+		if err != nil {
+			t.Error(err)
+		}
+
+		return out.String(), skipped
+	}
+
+	if _, skipped := run(false); skipped {
+		t.Error("The task should run the first time")
+	}
+
+	if _, skipped := run(false); !skipped {
+		t.Error("The task should be skipped because nothing changed")
+	}
+
+	if _, skipped := run(true); skipped {
+		t.Error("The task should run because it was forced")
+	}
+
+	err = ioutil.WriteFile(source, []byte("world"), 0644)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if out, skipped := run(false); skipped || out != "build\n" {
+		t.Error("The task should run because a source changed")
+	}
+
+	err = os.Remove(filepath.Join(dir, "main.out"))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if _, skipped := run(false); skipped {
+		t.Error("The task should run because an output was removed")
+	}
+
+	task := e.Tasks["build"]
+	task.Env["BUILD"] = "release"
+	e.Tasks["build"] = task
+
+	if _, skipped := run(false); skipped {
+		t.Error("The task should run because the env changed")
+	}
+
+	task = e.Tasks["build"]
+	task.Cmds = ox.NewCmds("cat main.txt > main.out", "echo build again")
+	e.Tasks["build"] = task
+
+	if _, skipped := run(false); skipped {
+		t.Error("The task should run because a command changed")
+	}
+}
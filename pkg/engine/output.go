@@ -0,0 +1,119 @@
+package engine
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/jjzcru/elk/pkg/primitives/ox"
+)
+
+// OutputEnv is the env variable with the path of the file where a task writes
+// its outputs as KEY=VALUE lines
+const OutputEnv = "ELK_OUTPUT"
+
+type outputsKey struct{}
+
+// outputs stores the outputs of the tasks of a run so the tasks that depend
+// on them can use them in their commands
+type outputs struct {
+	mu    sync.Mutex
+	tasks map[string]map[string]string
+}
+
+func newOutputs() *outputs {
+	return &outputs{
+		tasks: make(map[string]map[string]string),
+	}
+}
+
+func withOutputs(ctx context.Context, o *outputs) context.Context {
+	return context.WithValue(ctx, outputsKey{}, o)
+}
+
+func getOutputs(ctx context.Context) *outputs {
+	o, _ := ctx.Value(outputsKey{}).(*outputs)
+	return o
+}
+
+func (o *outputs) set(task string, values map[string]string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.tasks[task] = values
+}
+
+// deps returns the outputs of the dependencies of a task
+func (o *outputs) deps(task *ox.Task) map[string]map[string]string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	deps := make(map[string]map[string]string)
+	for _, dep := range task.Deps {
+		values, ok := o.tasks[dep.Name]
+		if !ok {
+			values = make(map[string]string)
+		}
+		deps[dep.Name] = values
+	}
+
+	return deps
+}
+
+// createOutputFile creates an empty file where a task can write its outputs
+func createOutputFile() (string, error) {
+	f, err := ioutil.TempFile("", "elk-output-")
+	if err != nil {
+		return "", err
+	}
+
+	return f.Name(), f.Close()
+}
+
+// readOutputs returns the KEY=VALUE lines written by a task to its output file
+func readOutputs(name string, path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return parseOutputs(name, f)
+}
+
+// parseOutputs returns the values from KEY=VALUE lines, empty lines are ignored
+func parseOutputs(name string, r io.Reader) (map[string]string, error) {
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 {
+			continue
+		}
+
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) != 2 || len(parts[0]) == 0 {
+			return nil, fmt.Errorf("invalid output '%s' from task '%s', it should be KEY=VALUE", line, name)
+		}
+
+		values[parts[0]] = parts[1]
+	}
+
+	return values, scanner.Err()
+}
+
+// formatOutputs returns the values as sorted KEY=VALUE lines
+func formatOutputs(values map[string]string) string {
+	var lines []string
+	for k, v := range values {
+		lines = append(lines, fmt.Sprintf("%s=%s\n", k, v))
+	}
+	sort.Strings(lines)
+
+	return strings.Join(lines, "")
+}
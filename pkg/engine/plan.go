@@ -0,0 +1,194 @@
+package engine
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jjzcru/elk/pkg/primitives/ox"
+)
+
+// PlannedTask is how a task is going to run, it is returned by a dry run
+type PlannedTask struct {
+	Task     string
+	Detached bool
+	Dir      string
+	Deps     []string
+
+	// Env are the env variables of the task that are not inherited from the
+	// system or that overwrite a variable of the system
+	Env map[string]string
+
+	// Cmds are the commands of the task after the vars are applied, the
+	// outputs of the dependencies and the values declared as shell commands
+	// are placeholders because they do not run
+	Cmds []string
+
+	// Hooks are the hooks of the task in the order that they are declared
+	Hooks []PlannedHook
+}
+
+// PlannedHook is a hook of a planned task, a command after the vars are applied
+// or a task, the tasks used as hooks are planned too
+type PlannedHook struct {
+	// Type is when the hook runs, it can be before, after, on_failure or finally
+	Type string
+	Cmd  string
+	Task string
+}
+
+// DryRun returns the tasks and its dependencies in the order that they run
+// with their resolved commands, without running anything
+func (e *Engine) DryRun(tasks ...string) ([]*PlannedTask, error) {
+	for _, task := range tasks {
+		if !e.Elk.HasTask(task) {
+			return nil, fmt.Errorf("%w: %s", ox.ErrTaskNotFound, task)
+		}
+	}
+
+	// The values declared as shell commands are not evaluated
+	e.Elk.PlaceholderSh(tasks...)
+
+	plan, err := e.Plan(tasks...)
+	if err != nil {
+		return nil, err
+	}
+
+	// A task runs detached when all the tasks that depend on it run it as a
+	// detached dependency
+	attached := make(map[string]bool)
+	for _, task := range tasks {
+		attached[task] = true
+	}
+
+	// The tasks used as hooks run after the task that uses them
+	plan, err = e.planHooks(plan)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range plan {
+		task := e.Elk.Tasks[name]
+		for _, hook := range task.GetHooks() {
+			if len(hook.Task) > 0 {
+				attached[hook.Task] = true
+			}
+		}
+	}
+
+	for _, name := range plan {
+		for _, dep := range e.Elk.Tasks[name].Deps {
+			if !dep.Detached {
+				attached[dep.Name] = true
+			}
+		}
+	}
+
+	osEnv := MapEnvs(os.Environ())
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+
+	var planned []*PlannedTask
+	for _, name := range plan {
+		task := e.Elk.Tasks[name]
+
+		p := &PlannedTask{
+			Task:     name,
+			Detached: !attached[name],
+			Dir:      task.Dir,
+			Deps:     []string{},
+			Env:      make(map[string]string),
+			Cmds:     []string{},
+			Hooks:    []PlannedHook{},
+		}
+
+		if len(p.Dir) == 0 {
+			p.Dir = cwd
+		}
+
+		for _, dep := range task.Deps {
+			p.Deps = append(p.Deps, dep.Name)
+		}
+
+		for k, v := range task.Env {
+			if value, ok := osEnv[k]; !ok || value != v {
+				p.Env[k] = v
+			}
+		}
+
+		for _, command := range task.Cmds {
+			cmd, err := ox.GetCmdWithPlaceholders(&task, command.Cmd)
+			if err != nil {
+				return nil, err
+			}
+
+			p.Cmds = append(p.Cmds, cmd)
+		}
+
+		hooks := []struct {
+			kind  string
+			hooks []ox.Hook
+		}{
+			{"before", task.Before},
+			{"after", task.After},
+			{"on_failure", task.OnFailure},
+			{"finally", task.Finally},
+		}
+
+		for _, h := range hooks {
+			for _, hook := range h.hooks {
+				plannedHook := PlannedHook{
+					Type: h.kind,
+					Task: hook.Task,
+				}
+
+				if len(hook.Task) == 0 {
+					plannedHook.Cmd, err = ox.GetCmdWithPlaceholders(&task, hook.Cmd)
+					if err != nil {
+						return nil, err
+					}
+				}
+
+				p.Hooks = append(p.Hooks, plannedHook)
+			}
+		}
+
+		planned = append(planned, p)
+	}
+
+	return planned, nil
+}
+
+// planHooks adds to a plan the tasks used as hooks by its tasks with their
+// deps, they are added after the other tasks
+func (e *Engine) planHooks(plan []string) ([]string, error) {
+	inPlan := make(map[string]bool)
+	for _, name := range plan {
+		inPlan[name] = true
+	}
+
+	for i := 0; i < len(plan); i++ {
+		task := e.Elk.Tasks[plan[i]]
+		for _, hook := range task.GetHooks() {
+			if len(hook.Task) == 0 {
+				continue
+			}
+
+			hookPlan, err := e.Plan(hook.Task)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, name := range hookPlan {
+				if !inPlan[name] {
+					inPlan[name] = true
+					plan = append(plan, name)
+				}
+			}
+		}
+	}
+
+	return plan, nil
+}
@@ -0,0 +1,154 @@
+package engine
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/jjzcru/elk/pkg/primitives/ox"
+)
+
+func TestDryRun(t *testing.T) {
+	e := &ox.Elk{
+		Vars: map[string]string{
+			"name": "elk",
+		},
+		Tasks: map[string]ox.Task{
+			"build": {
+				Dir:  os.TempDir(),
+				Cmds: ox.NewCmds("go build -o {{.name}}"),
+			},
+			"db": {
+				Cmds: ox.NewCmds("docker-compose up"),
+			},
+			"deploy": {
+				Env: map[string]string{
+					"REGION": "us-east-1",
+				},
+				Deps: []ox.Dep{
+					{Name: "build"},
+					{Name: "db", Detached: true},
+				},
+				Cmds: ox.NewCmds("./deploy.sh {{.name}}"),
+			},
+		},
+	}
+
+	err := e.Build()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	plan, err := (&Engine{Elk: e}).DryRun("deploy")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	var order []string
+	for _, task := range plan {
+		order = append(order, task.Task)
+	}
+
+	expected := []string{"build", "db", "deploy"}
+	if !reflect.DeepEqual(order, expected) {
+		t.Errorf("The plan should be %v but it was %v instead", expected, order)
+		return
+	}
+
+	build, db, deploy := plan[0], plan[1], plan[2]
+
+	if build.Dir != os.TempDir() || build.Cmds[0] != "go build -o elk" {
+		t.Errorf("The task '%s' should run '%s' in '%s' but it runs '%s' in '%s'", "build", "go build -o elk", os.TempDir(), build.Cmds[0], build.Dir)
+	}
+
+	if build.Detached || !db.Detached || deploy.Detached {
+		t.Errorf("Only the task '%s' should run detached", "db")
+	}
+
+	if len(build.Env) != 0 {
+		t.Errorf("The task '%s' should not have env variables that are not in the system but it has %v", "build", build.Env)
+	}
+
+	if !reflect.DeepEqual(deploy.Env, map[string]string{"REGION": "us-east-1"}) {
+		t.Errorf("The task '%s' should only have the env variable '%s' but it has %v", "deploy", "REGION", deploy.Env)
+	}
+
+	if !reflect.DeepEqual(deploy.Deps, []string{"build", "db"}) {
+		t.Errorf("The task '%s' should depend on %v but it depends on %v", "deploy", []string{"build", "db"}, deploy.Deps)
+	}
+}
+
+func TestDryRunHooksAndSh(t *testing.T) {
+	dir, err := ioutil.TempDir("", "elk-plan-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	ran := filepath.Join(dir, "ran")
+
+	e := &ox.Elk{
+		Tasks: map[string]ox.Task{
+			"setup": {
+				Cmds: ox.NewCmds("./setup.sh"),
+			},
+			"notify": {
+				Deps: []ox.Dep{{Name: "setup"}},
+				Cmds: ox.NewCmds("./notify.sh"),
+			},
+			"deploy": {
+				VarsSh: map[string]string{
+					"version": "touch " + ran,
+				},
+				Before:  []ox.Hook{{Cmd: "echo {{.version}}"}},
+				Finally: []ox.Hook{{Task: "notify"}},
+				Cmds:    ox.NewCmds("./deploy.sh {{.version}}"),
+			},
+		},
+	}
+
+	err = e.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plan, err := (&Engine{Elk: e}).DryRun("deploy")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(ran); err == nil {
+		t.Error("The shell commands of the vars should not run in a dry run")
+	}
+
+	var order []string
+	for _, task := range plan {
+		order = append(order, task.Task)
+	}
+
+	expected := []string{"deploy", "setup", "notify"}
+	if !reflect.DeepEqual(order, expected) {
+		t.Fatalf("The plan should be %v but it was %v instead", expected, order)
+	}
+
+	deploy := plan[0]
+	if deploy.Cmds[0] != "./deploy.sh <sh: touch "+ran+">" {
+		t.Errorf("The var should be a placeholder with its command but the command was '%s'", deploy.Cmds[0])
+	}
+
+	hooks := []PlannedHook{
+		{Type: "before", Cmd: "echo <sh: touch " + ran + ">"},
+		{Type: "finally", Task: "notify"},
+	}
+	if !reflect.DeepEqual(deploy.Hooks, hooks) {
+		t.Errorf("The hooks should be %v but they were %v instead", hooks, deploy.Hooks)
+	}
+
+	if plan[1].Detached || plan[2].Detached {
+		t.Error("The task used as a hook and its deps should not run detached")
+	}
+}
@@ -0,0 +1,124 @@
+package engine
+
+import (
+	"context"
+	"time"
+)
+
+// Status is the final state of a task or a command
+type Status string
+
+const (
+	// StatusSuccess is used when a task or a command finished without errors
+	StatusSuccess Status = "success"
+
+	// StatusFailed is used when a task or a command returned an error
+	StatusFailed Status = "failed"
+
+	// StatusSkipped is used when a task did not run because its if condition
+	// is false or because it is up to date
+	StatusSkipped Status = "skipped"
+
+	// StatusCancelled is used when a task or a command was stopped before it finished
+	StatusCancelled Status = "cancelled"
+
+	// StatusTimeout is used when a task or a command ran longer than its timeout
+	StatusTimeout Status = "timeout"
+)
+
+// Result is the outcome of a task, with the result of each of its commands and
+// of each of the dependencies it waited for
+type Result struct {
+	Task     string
+	Status   Status
+	ExitCode int
+	StartAt  time.Time
+	EndAt    time.Time
+
+	// Attempts is the highest attempt number reached by a command of the task
+	Attempts int
+
+	// Outputs are the values the task wrote to the file in ELK_OUTPUT
+	Outputs map[string]string
+
+	Cmds []*CmdResult
+	Deps []*Result
+	Err  error
+}
+
+// CmdResult is the outcome of a command of a task
+type CmdResult struct {
+	Cmd      string
+	Status   Status
+	ExitCode int
+	StartAt  time.Time
+	EndAt    time.Time
+	Attempts int
+	Err      error
+}
+
+// Duration returns how long the task ran
+func (r *Result) Duration() time.Duration {
+	return r.EndAt.Sub(r.StartAt)
+}
+
+// Duration returns how long the command ran, including all its attempts
+func (r *CmdResult) Duration() time.Duration {
+	return r.EndAt.Sub(r.StartAt)
+}
+
+// finish sets the status, exit code and end time of the result from the error
+// returned by the task
+func (r *Result) finish(ctx context.Context, err error) {
+	r.EndAt = time.Now()
+	r.Err = err
+	r.Status, r.ExitCode = GetStatus(ctx, err)
+}
+
+// finish sets the status, exit code and end time of the result from the error
+// returned by the last attempt of the command
+func (r *CmdResult) finish(ctx context.Context, err error) {
+	r.EndAt = time.Now()
+	r.Err = err
+	r.Status, r.ExitCode = GetStatus(ctx, err)
+}
+
+// GetStatus returns the status and the exit code that matches an error, ctx
+// is the context of the task or command that returned the error
+func GetStatus(ctx context.Context, err error) (Status, int) {
+	if err == nil {
+		return StatusSuccess, 0
+	}
+
+	err = unwrapError(err)
+
+	exitCode, ok := getExitCode(err)
+	if !ok {
+		exitCode = 1
+	}
+
+	_, isTimeout := err.(*TimeoutError)
+	switch {
+	case isTimeout:
+		return StatusTimeout, exitCode
+	case ctx.Err() != nil:
+		return StatusCancelled, exitCode
+	default:
+		return StatusFailed, exitCode
+	}
+}
+
+// unwrapError returns the error that made a task fail, when there are many
+// errors the first one is used because the rest are from hooks or other tasks
+func unwrapError(err error) error {
+	for {
+		switch e := err.(type) {
+		case Errors:
+			err = e[0]
+		case *TaskError:
+			err = e.Err
+		default:
+			return err
+		}
+	}
+}
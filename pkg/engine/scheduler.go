@@ -0,0 +1,340 @@
+package engine
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jjzcru/elk/pkg/primitives/ox"
+)
+
+// tty is held by the interactive task that is running, so only one of them
+// reads from the terminal at a time, even across runs
+var tty sync.Mutex
+
+type schedulerKey struct{}
+
+type nodeKey struct{}
+
+// scheduler runs each task of a run at most once, tasks that depend on a task
+// that is already running wait for it to finish instead of running it again.
+// Only the tasks used as hooks run each time a hook uses them
+type scheduler struct {
+	ctx      context.Context
+	elk      *ox.Elk
+	executer Executer
+
+	// slots limits the amount of tasks running at the same time, nil means no limit
+	slots chan struct{}
+
+	mu    sync.Mutex
+	nodes map[string]*node
+	errs  Errors
+
+	// hooks are the nodes of the tasks that run as hooks, they are not
+	// shared so they always run after the task that uses them
+	hooks []*node
+}
+
+// node is the state of a task inside a run
+type node struct {
+	done     chan struct{}
+	cancel   context.CancelFunc
+	detached bool
+	released bool
+	result   *Result
+	err      error
+
+	// base is the context of the run or of the hook that started the task,
+	// its deps start from it
+	base context.Context
+
+	// slot and tty are set while the task holds them, so they can be
+	// given up while it waits for a hook task
+	slot bool
+	tty  bool
+}
+
+func withScheduler(ctx context.Context, s *scheduler) context.Context {
+	return context.WithValue(ctx, schedulerKey{}, s)
+}
+
+func getScheduler(ctx context.Context) *scheduler {
+	s, _ := ctx.Value(schedulerKey{}).(*scheduler)
+	return s
+}
+
+func withNode(ctx context.Context, n *node) context.Context {
+	return context.WithValue(ctx, nodeKey{}, n)
+}
+
+func getNode(ctx context.Context) *node {
+	n, _ := ctx.Value(nodeKey{}).(*node)
+	return n
+}
+
+func newScheduler(ctx context.Context, elk *ox.Elk, executer Executer) *scheduler {
+	s := &scheduler{
+		elk:      elk,
+		executer: executer,
+		nodes:    make(map[string]*node),
+	}
+	s.ctx = withScheduler(ctx, s)
+
+	if elk.Concurrency > 0 {
+		s.slots = make(chan struct{}, elk.Concurrency)
+	}
+
+	return s
+}
+
+// start runs a task in the background from base if it was not started before
+func (s *scheduler) start(base context.Context, name string, detached bool) *node {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if n, ok := s.nodes[name]; ok {
+		if !detached {
+			n.detached = false
+		}
+		return n
+	}
+
+	ctx, cancel := context.WithCancel(base)
+	n := &node{
+		done:     make(chan struct{}),
+		cancel:   cancel,
+		detached: detached,
+		base:     base,
+	}
+	s.nodes[name] = n
+
+	go s.execute(ctx, name, n)
+
+	return n
+}
+
+// run starts a task if is not running and waits until it finish
+func (s *scheduler) run(ctx context.Context, name string) (*Result, error) {
+	return s.await(ctx, name, s.start(s.ctx, name, false))
+}
+
+// runHook runs a task used as a hook, its deps run like the deps of any
+// other task but the task itself runs again even if it already ran, so it
+// always runs after the task that uses it. The task starts from the context of
+// the hook and the task that uses it gives up its slot and the terminal until
+// the hook finish, otherwise they could wait for each other
+func (s *scheduler) runHook(ctx context.Context, name string) (*Result, error) {
+	caller := getNode(ctx)
+	if caller != nil && caller.slot {
+		<-s.slots
+		defer func() { s.slots <- struct{}{} }()
+	}
+
+	if caller != nil && caller.tty {
+		tty.Unlock()
+		defer tty.Lock()
+	}
+
+	hookCtx, cancel := context.WithCancel(ctx)
+	n := &node{
+		done:   make(chan struct{}),
+		cancel: cancel,
+		base:   ctx,
+	}
+
+	s.mu.Lock()
+	s.hooks = append(s.hooks, n)
+	s.mu.Unlock()
+
+	go s.execute(hookCtx, name, n)
+
+	return s.await(ctx, name, n)
+}
+
+// await waits until a task finish or ctx is done
+func (s *scheduler) await(ctx context.Context, name string, n *node) (*Result, error) {
+	select {
+	case <-n.done:
+		return n.result, n.err
+	case <-ctx.Done():
+		return &Result{Task: name, Status: StatusCancelled, Err: ctx.Err()}, ctx.Err()
+	}
+}
+
+// wait blocks until every task that was started finish, the tasks that were
+// cancelled finish once their processes stop and their hooks run
+func (s *scheduler) wait() {
+	for waited := 0; ; {
+		s.mu.Lock()
+		nodes := make([]*node, 0, len(s.nodes)+len(s.hooks))
+		for _, n := range s.nodes {
+			nodes = append(nodes, n)
+		}
+		nodes = append(nodes, s.hooks...)
+		s.mu.Unlock()
+
+		if len(nodes) == waited {
+			return
+		}
+
+		for _, n := range nodes {
+			<-n.done
+		}
+		waited = len(nodes)
+	}
+}
+
+// release cancels a detached task once the task that started it is done,
+// unless another task is waiting for its result
+func (s *scheduler) release(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if n, ok := s.nodes[name]; ok && n.detached {
+		n.released = true
+		n.cancel()
+	}
+}
+
+// detachedErrors returns the errors from detached tasks that nobody was waiting for
+func (s *scheduler) detachedErrors() Errors {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.errs
+}
+
+func (s *scheduler) execute(ctx context.Context, name string, n *node) {
+	defer close(n.done)
+	defer n.cancel()
+	defer func() {
+		getEvents(ctx).emit(Event{
+			Type:     TaskFinished,
+			Task:     name,
+			Status:   n.result.Status,
+			ExitCode: n.result.ExitCode,
+		})
+	}()
+
+	task := s.elk.Tasks[name]
+
+	for _, dep := range task.Deps {
+		if dep.Detached {
+			s.start(n.base, dep.Name, true)
+			defer s.release(dep.Name)
+		}
+	}
+
+	n.result = &Result{
+		Task:    name,
+		StartAt: time.Now(),
+	}
+
+	deps, err := s.runDeps(ctx, n.base, task.Deps)
+	n.result.Deps = deps
+	if err != nil {
+		n.err = err
+		n.result.finish(ctx, err)
+
+		// The task did not run, so it can not time out
+		if n.result.Status == StatusTimeout {
+			n.result.Status = StatusFailed
+		}
+		return
+	}
+
+	// Detached tasks do not take a slot, otherwise a long running task
+	// could block its dependents forever
+	s.mu.Lock()
+	detached := n.detached
+	s.mu.Unlock()
+
+	if !detached && s.slots != nil {
+		select {
+		case s.slots <- struct{}{}:
+			n.slot = true
+			defer func() { <-s.slots }()
+		case <-ctx.Done():
+			n.err = ctx.Err()
+			n.result.finish(ctx, n.err)
+			return
+		}
+	}
+
+	if task.Interactive {
+		if detached {
+			n.err = &InteractiveError{Task: name}
+			n.result.finish(ctx, n.err)
+
+			s.mu.Lock()
+			if !n.released {
+				s.errs = append(s.errs, &TaskError{Task: name, Err: n.err})
+			}
+			s.mu.Unlock()
+			return
+		}
+
+		tty.Lock()
+		n.tty = true
+		defer tty.Unlock()
+	}
+
+	getEvents(ctx).emit(Event{
+		Type: TaskStarted,
+		Task: name,
+	})
+
+	// The hooks of the task find its node to give up its slot and the terminal
+	result, err := s.executer.Execute(withNode(ctx, n), s.elk, name)
+	if result == nil {
+		result = n.result
+		result.finish(ctx, err)
+	}
+	result.Deps = deps
+	n.result, n.err = result, err
+
+	if n.err != nil {
+		s.mu.Lock()
+		if n.detached && !n.released {
+			s.errs = append(s.errs, &TaskError{Task: name, Err: n.err})
+		}
+		s.mu.Unlock()
+	}
+}
+
+// runDeps starts the dependencies that are not detached from base, runs them
+// in parallel and returns their results and the errors of the ones that are
+// not ignored
+func (s *scheduler) runDeps(ctx context.Context, base context.Context, deps []ox.Dep) ([]*Result, error) {
+	var wg sync.WaitGroup
+	results := make([]*Result, len(deps))
+	errs := make([]error, len(deps))
+
+	for i, dep := range deps {
+		if dep.Detached {
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, dep ox.Dep) {
+			defer wg.Done()
+			result, err := s.await(ctx, dep.Name, s.start(base, dep.Name, false))
+			results[i] = result
+			if err != nil && !dep.IgnoreError {
+				errs[i] = &TaskError{Task: dep.Name, Err: err}
+			}
+		}(i, dep)
+	}
+
+	wg.Wait()
+
+	var awaited []*Result
+	for _, result := range results {
+		if result != nil {
+			awaited = append(awaited, result)
+		}
+	}
+
+	return awaited, collectErrors(errs)
+}
@@ -0,0 +1,217 @@
+package ox
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jjzcru/elk/pkg/maps"
+)
+
+// Types of the args of a task
+const (
+	ArgString = "string"
+	ArgInt    = "int"
+	ArgBool   = "bool"
+	ArgEnum   = "enum"
+)
+
+// Arg is a value that a task receives when it runs, it is available in the
+// templates of the task like a var
+type Arg struct {
+	Name        string   `yaml:"name"`
+	Type        string   `yaml:"type,omitempty"`
+	Values      []string `yaml:"values,omitempty"`
+	Default     string   `yaml:"default,omitempty"`
+	Required    bool     `yaml:"required,omitempty"`
+	Description string   `yaml:"description,omitempty"`
+}
+
+// GetType returns the type of the arg, string if it is not set
+func (a Arg) GetType() string {
+	if len(a.Type) == 0 {
+		return ArgString
+	}
+
+	return a.Type
+}
+
+// Parse returns the value of the arg after checking that it is valid for its
+// type, bool values like yes, on or 1 are returned as true or false
+func (a Arg) Parse(value string) (string, error) {
+	switch a.GetType() {
+	case ArgInt:
+		_, err := strconv.Atoi(value)
+		if err != nil {
+			return "", fmt.Errorf("%w: '%s' of arg '%s' should be an int", ErrInvalidArgValue, value, a.Name)
+		}
+	case ArgBool:
+		switch strings.ToLower(value) {
+		case "yes", "y", "on":
+			return "true", nil
+		case "no", "n", "off":
+			return "false", nil
+		}
+
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", fmt.Errorf("%w: '%s' of arg '%s' should be a bool", ErrInvalidArgValue, value, a.Name)
+		}
+		return strconv.FormatBool(b), nil
+	case ArgEnum:
+		for _, v := range a.Values {
+			if v == value {
+				return value, nil
+			}
+		}
+		return "", fmt.Errorf("%w: '%s' of arg '%s' should be one of %s", ErrInvalidArgValue, value, a.Name, strings.Join(a.Values, ", "))
+	}
+
+	return value, nil
+}
+
+// validateArgs checks that the args of a task are well declared
+func validateArgs(args []Arg) error {
+	names := make(map[string]bool)
+	for _, arg := range args {
+		if len(arg.Name) == 0 {
+			return fmt.Errorf("%w: an arg does not have a name", ErrInvalidArg)
+		}
+
+		if names[arg.Name] {
+			return fmt.Errorf("%w: arg '%s' is declared more than once", ErrInvalidArg, arg.Name)
+		}
+		names[arg.Name] = true
+
+		switch arg.GetType() {
+		case ArgString, ArgInt, ArgBool:
+		case ArgEnum:
+			if len(arg.Values) == 0 {
+				return fmt.Errorf("%w: arg '%s' is an enum without values", ErrInvalidArg, arg.Name)
+			}
+		default:
+			return fmt.Errorf("%w: type of arg '%s' should be string, int, bool or enum", ErrInvalidArg, arg.Name)
+		}
+
+		if len(arg.Default) > 0 {
+			_, err := arg.Parse(arg.Default)
+			if err != nil {
+				return fmt.Errorf("%w: default of arg '%s' is not valid", ErrInvalidArg, arg.Name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// GetArgs returns the values of the args of the task by name, values are set
+// by name and positional in the order in which the args are declared. Args
+// without a value use their default, bool args are false by default and the
+// other args are empty
+func (t *Task) GetArgs(values map[string]string, positional []string) (map[string]string, error) {
+	if len(positional) > len(t.Args) {
+		return nil, fmt.Errorf("%w: the task receives %d args but %d were given", ErrUnknownArg, len(t.Args), len(positional))
+	}
+
+	declared := make(map[string]bool)
+	for _, arg := range t.Args {
+		declared[arg.Name] = true
+	}
+
+	for name := range values {
+		if !declared[name] {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownArg, name)
+		}
+	}
+
+	args := make(map[string]string)
+	for i, arg := range t.Args {
+		value, ok := values[arg.Name]
+		if i < len(positional) {
+			if ok {
+				return nil, fmt.Errorf("%w: arg '%s' is set by name and by position", ErrInvalidArgValue, arg.Name)
+			}
+			value, ok = positional[i], true
+		}
+
+		if !ok {
+			if arg.Required {
+				return nil, fmt.Errorf("%w: %s", ErrMissingArg, arg.Name)
+			}
+
+			value = arg.Default
+			if len(value) == 0 && arg.GetType() == ArgBool {
+				value = "false"
+			}
+
+			// The templates can use an optional arg without a value
+			if len(value) == 0 {
+				args[arg.Name] = ""
+				continue
+			}
+		}
+
+		value, err := arg.Parse(value)
+		if err != nil {
+			return nil, err
+		}
+
+		args[arg.Name] = value
+	}
+
+	return args, nil
+}
+
+// SetArgs validates the values of the args of the tasks and adds them to the
+// vars of each task, a value by name is used by all the tasks that declare
+// the arg. Positional values can only be used with a single task
+func (e *Elk) SetArgs(tasks []string, values map[string]string, positional []string) error {
+	if len(positional) > 0 && len(tasks) > 1 {
+		return fmt.Errorf("%w: positional args can only be used with a single task", ErrInvalidArgValue)
+	}
+
+	declared := make(map[string]bool)
+	for _, name := range tasks {
+		task, err := e.GetTask(name)
+		if err != nil {
+			return fmt.Errorf("%w: %s", err, name)
+		}
+
+		for _, arg := range task.Args {
+			declared[arg.Name] = true
+		}
+	}
+
+	for name := range values {
+		if !declared[name] {
+			return fmt.Errorf("%w: %s", ErrUnknownArg, name)
+		}
+	}
+
+	for _, name := range tasks {
+		task := e.Tasks[name]
+
+		taskValues := make(map[string]string)
+		for _, arg := range task.Args {
+			if value, ok := values[arg.Name]; ok {
+				taskValues[arg.Name] = value
+			}
+		}
+
+		args, err := task.GetArgs(taskValues, positional)
+		if err != nil {
+			return fmt.Errorf("task '%s': %w", name, err)
+		}
+
+		task.Vars = maps.MergeMaps(task.Vars, args)
+		e.Tasks[name] = task
+
+		for _, instanceName := range task.instances {
+			instance := e.Tasks[instanceName]
+			instance.Vars = maps.MergeMaps(instance.Vars, args)
+			e.Tasks[instanceName] = instance
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package ox
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestArgParse(t *testing.T) {
+	tests := []struct {
+		arg      Arg
+		value    string
+		expected string
+		err      error
+	}{
+		{Arg{Name: "name"}, "elk", "elk", nil},
+		{Arg{Name: "count", Type: ArgInt}, "3", "3", nil},
+		{Arg{Name: "count", Type: ArgInt}, "three", "", ErrInvalidArgValue},
+		{Arg{Name: "force", Type: ArgBool}, "1", "true", nil},
+		{Arg{Name: "force", Type: ArgBool}, "maybe", "", ErrInvalidArgValue},
+		{Arg{Name: "env", Type: ArgEnum, Values: []string{"dev", "prod"}}, "prod", "prod", nil},
+		{Arg{Name: "env", Type: ArgEnum, Values: []string{"dev", "prod"}}, "qa", "", ErrInvalidArgValue},
+	}
+
+	for _, test := range tests {
+		value, err := test.arg.Parse(test.value)
+		if !errors.Is(err, test.err) {
+			t.Errorf("The error of '%s' should be '%v' but it was '%v' instead", test.value, test.err, err)
+			continue
+		}
+
+		if value != test.expected {
+			t.Errorf("The value should be '%s' but it was '%s' instead", test.expected, value)
+		}
+	}
+}
+
+func TestValidateArgs(t *testing.T) {
+	tests := [][]Arg{
+		{{Type: ArgInt}},
+		{{Name: "env"}, {Name: "env"}},
+		{{Name: "env", Type: "float"}},
+		{{Name: "env", Type: ArgEnum}},
+		{{Name: "count", Type: ArgInt, Default: "many"}},
+	}
+
+	for _, args := range tests {
+		err := validateArgs(args)
+		if !errors.Is(err, ErrInvalidArg) {
+			t.Errorf("The error should be '%v' but it was '%v' instead", ErrInvalidArg, err)
+		}
+	}
+
+	err := validateArgs([]Arg{
+		{Name: "env", Type: ArgEnum, Values: []string{"dev", "prod"}, Default: "dev"},
+		{Name: "count", Type: ArgInt, Required: true},
+	})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestElkSetArgs(t *testing.T) {
+	e := Elk{
+		Tasks: map[string]Task{
+			"deploy": {
+				Vars: map[string]string{"env": "local"},
+				Args: []Arg{
+					{Name: "env", Type: ArgEnum, Values: []string{"dev", "prod"}, Required: true},
+					{Name: "replicas", Type: ArgInt, Default: "1"},
+					{Name: "force", Type: ArgBool},
+					{Name: "tag"},
+				},
+			},
+			"build": {},
+		},
+	}
+
+	err := e.SetArgs([]string{"deploy"}, map[string]string{"force": "yes"}, []string{"prod"})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	expected := map[string]string{
+		"env":      "prod",
+		"replicas": "1",
+		"force":    "true",
+		"tag":      "",
+	}
+
+	for name, value := range expected {
+		if _, ok := e.Tasks["deploy"].Vars[name]; !ok {
+			t.Errorf("The var '%s' should be set", name)
+		}
+
+		if e.Tasks["deploy"].Vars[name] != value {
+			t.Errorf("The var '%s' should be '%s' but it was '%s' instead", name, value, e.Tasks["deploy"].Vars[name])
+		}
+	}
+
+	tests := []struct {
+		tasks      []string
+		values     map[string]string
+		positional []string
+		err        error
+	}{
+		{[]string{"deploy"}, nil, nil, ErrMissingArg},
+		{[]string{"deploy"}, map[string]string{"env": "prod", "region": "us"}, nil, ErrUnknownArg},
+		{[]string{"build"}, map[string]string{"env": "prod"}, nil, ErrUnknownArg},
+		{[]string{"deploy"}, map[string]string{"env": "qa"}, nil, ErrInvalidArgValue},
+		{[]string{"deploy"}, nil, []string{"prod", "2", "true", "v1", "extra"}, ErrUnknownArg},
+		{[]string{"deploy", "build"}, nil, []string{"prod"}, ErrInvalidArgValue},
+	}
+
+	for _, test := range tests {
+		err := e.SetArgs(test.tasks, test.values, test.positional)
+		if !errors.Is(err, test.err) {
+			t.Errorf("The error should be '%v' but it was '%v' instead", test.err, err)
+		}
+	}
+}
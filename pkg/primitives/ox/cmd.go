@@ -0,0 +1,65 @@
+package ox
+
+import "time"
+
+// Cmd is a command from a task, it can be declared as a string or as an object
+// when the command requires its own properties
+type Cmd struct {
+	Cmd     string        `yaml:"cmd"`
+	Retry   *Retry        `yaml:"retry,omitempty"`
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+	Shell   string        `yaml:"shell,omitempty"`
+}
+
+// UnmarshalYAML reads a command from a string or from an object
+func (c *Cmd) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var cmd string
+	err := unmarshal(&cmd)
+	if err == nil {
+		c.Cmd = cmd
+		return nil
+	}
+
+	type plain Cmd
+	return unmarshal((*plain)(c))
+}
+
+// MarshalYAML writes the command as a string if it do not have other properties
+func (c Cmd) MarshalYAML() (interface{}, error) {
+	if c == (Cmd{Cmd: c.Cmd}) {
+		return c.Cmd, nil
+	}
+
+	type plain Cmd
+	return plain(c), nil
+}
+
+// GetRetry returns the retry policy of the command, if the command do not
+// declare one it uses the one from the task
+func (c Cmd) GetRetry(task *Task) *Retry {
+	if c.Retry != nil {
+		return c.Retry
+	}
+
+	return task.Retry
+}
+
+// GetShell returns the shell of the command, if the command do not declare
+// one it uses the one from the task
+func (c Cmd) GetShell(task *Task) string {
+	if len(c.Shell) > 0 {
+		return c.Shell
+	}
+
+	return task.Shell
+}
+
+// NewCmds creates a list of commands from strings
+func NewCmds(cmds ...string) []Cmd {
+	var result []Cmd
+	for _, cmd := range cmds {
+		result = append(result, Cmd{Cmd: cmd})
+	}
+
+	return result
+}
@@ -0,0 +1,67 @@
+package ox
+
+import (
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+func TestCmdUnmarshalYAML(t *testing.T) {
+	content := `
+cmds:
+  - echo Hello
+  - cmd: curl localhost
+    retry:
+      attempts: 3
+      delay: 1s
+`
+	task := Task{}
+	err := yaml.Unmarshal([]byte(content), &task)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if len(task.Cmds) != 2 {
+		t.Errorf("The task should have %d commands but it has %d instead", 2, len(task.Cmds))
+		return
+	}
+
+	if task.Cmds[0].Cmd != "echo Hello" {
+		t.Errorf("The command should be '%s' but it was '%s' instead", "echo Hello", task.Cmds[0].Cmd)
+	}
+
+	if task.Cmds[1].Retry == nil || task.Cmds[1].Retry.Attempts != 3 || task.Cmds[1].Retry.Delay != time.Second {
+		t.Error("The command should have a retry policy")
+	}
+}
+
+func TestCmdMarshalYAML(t *testing.T) {
+	content, err := yaml.Marshal(NewCmds("echo Hello"))
+	if err != nil {
+		t.Error(err)
+	}
+
+	if string(content) != "- echo Hello\n" {
+		t.Errorf("A command without properties should be written as a string but it was '%s'", string(content))
+	}
+}
+
+func TestCmdGetRetry(t *testing.T) {
+	task := Task{
+		Retry: &Retry{Attempts: 2},
+		Cmds: []Cmd{
+			{Cmd: "echo Hello"},
+			{Cmd: "echo World", Retry: &Retry{Attempts: 3}},
+		},
+	}
+
+	if task.Cmds[0].GetRetry(&task).Attempts != 2 {
+		t.Error("The command should use the retry policy from the task")
+	}
+
+	if task.Cmds[1].GetRetry(&task).Attempts != 3 {
+		t.Error("The command should use its own retry policy")
+	}
+}
@@ -4,21 +4,75 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/jjzcru/elk/pkg/file"
 	"github.com/jjzcru/elk/pkg/maps"
+	"github.com/jjzcru/elk/pkg/shell"
 	"gopkg.in/yaml.v2"
 )
 
 // Elk is the structure of the application
 type Elk struct {
-	filePath string
-	Version  string
-	Env      map[string]string `yaml:"env"`
-	Vars     map[string]string `yaml:"vars"`
-	EnvFile  string            `yaml:"env_file"`
-	Tasks    map[string]Task
+	filePath    string
+	Version     string
+	Env         map[string]string  `yaml:"-"`
+	Vars        map[string]string  `yaml:"-"`
+	EnvFile     string             `yaml:"env_file"`
+	Concurrency int                `yaml:"concurrency,omitempty"`
+	Shell       string             `yaml:"shell,omitempty"`
+	GracePeriod time.Duration      `yaml:"grace_period,omitempty"`
+	Include     []Include          `yaml:"include,omitempty"`
+	Profiles    map[string]Profile `yaml:"profiles,omitempty"`
+	Tasks       map[string]Task
+
+	// included are the names of the tasks that come from an included file,
+	// they are not written back to the file
+	included map[string]bool
+
+	// global is true when the object was loaded from the global file, its
+	// tasks without a dir run in the current directory
+	global bool
+
+	// EnvSh and VarsSh are the env variables and vars whose value is the output
+	// of a shell command, by name, Build passes them to the tasks and EvalSh
+	// evaluates them. They are read and written with Env and Vars by
+	// UnmarshalYAML and MarshalYAML
+	EnvSh  map[string]string `yaml:"-"`
+	VarsSh map[string]string `yaml:"-"`
+
+	// sh stores the output of the shell commands evaluated by EvalSh,
+	// envSh and varsSh are the values of EnvSh and VarsSh once evaluated
+	sh     shCache
+	envSh  map[string]string
+	varsSh map[string]string
+}
+
+// UnmarshalYAML reads an elk object where env and vars can be declared as {sh: cmd}
+func (e *Elk) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain Elk
+	var err error
+	e.EnvSh, e.VarsSh, err = unmarshalSh(unmarshal, (*plain)(e), &e.Env, &e.Vars)
+	return err
+}
+
+// MarshalYAML writes an elk object with the env and vars declared as {sh: cmd},
+// without the tasks of the included files
+func (e Elk) MarshalYAML() (interface{}, error) {
+	if len(e.included) > 0 {
+		tasks := make(map[string]Task)
+		for name, task := range e.Tasks {
+			if !e.included[name] {
+				tasks[name] = task
+			}
+		}
+		e.Tasks = tasks
+	}
+
+	type plain Elk
+	return marshalSh(plain(e), e.Env, e.EnvSh, e.Vars, e.VarsSh)
 }
 
 // GetTask Get a task object by its name
@@ -50,8 +104,75 @@ func (e *Elk) SetFilePath(filepath string) {
 	e.filePath = filepath
 }
 
+// SetGlobal set if the object was loaded from the global file
+func (e *Elk) SetGlobal(global bool) {
+	e.global = global
+}
+
+// GetDir get the directory of the file used to create the object, the relative
+// paths of the file are resolved against it
+func (e *Elk) GetDir() string {
+	if len(e.filePath) == 0 {
+		return ""
+	}
+
+	dir, err := filepath.Abs(filepath.Dir(e.filePath))
+	if err != nil {
+		return filepath.Dir(e.filePath)
+	}
+
+	return dir
+}
+
+// GetPath get a path resolved against the directory of the file used to create
+// the object
+func (e *Elk) GetPath(path string) string {
+	dir := e.GetDir()
+	if len(path) == 0 || len(dir) == 0 {
+		return path
+	}
+
+	return getPath(dir, path)
+}
+
+// getTaskDir get the directory where a task runs, a task without dir runs in
+// the directory of the file unless it comes from the global file
+func (e *Elk) getTaskDir(dir string) string {
+	if len(dir) == 0 && e.global {
+		return dir
+	}
+
+	if len(dir) == 0 {
+		return e.GetDir()
+	}
+
+	return e.GetPath(dir)
+}
+
 // Build compiles the ox structure and validates its integrity
 func (e *Elk) Build() error {
+	if e.Concurrency < 0 {
+		return ErrInvalidConcurrency
+	}
+
+	if !shell.IsValid(e.Shell) {
+		return ErrInvalidShell
+	}
+
+	if e.GracePeriod < 0 {
+		return ErrInvalidGracePeriod
+	}
+
+	err := e.ResolveExtends()
+	if err != nil {
+		return err
+	}
+
+	err = e.ExpandMatrix()
+	if err != nil {
+		return err
+	}
+
 	osEnvs := make(map[string]string)
 	for _, en := range os.Environ() {
 		parts := strings.SplitAfterN(en, "=", 2)
@@ -60,7 +181,8 @@ func (e *Elk) Build() error {
 		osEnvs[env] = value
 	}
 
-	err := e.LoadEnvFile()
+	e.EnvFile = e.GetPath(e.EnvFile)
+	err = e.LoadEnvFile()
 	if err != nil {
 		return err
 	}
@@ -77,13 +199,59 @@ func (e *Elk) Build() error {
 			return err
 		}
 
+		err = e.HasCircularHook(name)
+		if err != nil {
+			return err
+		}
+
+		task.Dir = e.getTaskDir(task.Dir)
+		task.EnvFile = e.GetPath(task.EnvFile)
+		task.Log.Out = e.GetPath(task.Log.Out)
+		task.Log.Err = e.GetPath(task.Log.Err)
+
 		err = task.LoadEnvFile()
 		if err != nil {
 			return err
 		}
 
-		task.Env = maps.MergeMaps(maps.CopyMap(e.Env), maps.CopyMap(task.Env))
-		task.Vars = maps.MergeMaps(maps.CopyMap(e.Vars), maps.CopyMap(task.Vars))
+		if len(task.Shell) == 0 {
+			task.Shell = e.Shell
+		}
+
+		if task.GracePeriod < 0 {
+			return ErrInvalidGracePeriod
+		}
+
+		if task.GracePeriod == 0 {
+			task.GracePeriod = e.GracePeriod
+		}
+
+		err = validateArgs(task.Args)
+		if err != nil {
+			return fmt.Errorf("task '%s': %w", name, err)
+		}
+
+		err = task.Limits.Validate()
+		if err != nil {
+			return fmt.Errorf("task '%s': %w", name, err)
+		}
+
+		for _, cmd := range task.Cmds {
+			if !shell.IsValid(cmd.Shell) {
+				return ErrInvalidShell
+			}
+		}
+
+		if !shell.IsValid(task.Shell) {
+			return ErrInvalidShell
+		}
+
+		// The shell commands only run for the tasks that are going to run,
+		// see EvalSh
+		task.globalEnvSh = inheritedSh(e.EnvSh, task.Env, task.EnvSh)
+		task.globalVarsSh = inheritedSh(e.VarsSh, task.Vars, task.VarsSh)
+		task.Env = maps.MergeMaps(e.Env, task.Env)
+		task.Vars = maps.MergeMaps(e.Vars, task.Vars)
 
 		e.Tasks[name] = task
 	}
@@ -91,6 +259,155 @@ func (e *Elk) Build() error {
 	return nil
 }
 
+// EvalSh runs the shell commands of the env variables and vars of the tasks,
+// their deps and the tasks that they use as hooks, it is called after Build.
+// A command runs once for each shell and directory, the values of the other
+// tasks are not evaluated
+func (e *Elk) EvalSh(tasks ...string) error {
+	return e.evalSh(tasks, false)
+}
+
+// PlaceholderSh sets the values declared as shell commands of the tasks, their
+// deps and the tasks that they use as hooks to a placeholder with the command,
+// like <sh: git describe>, without running them. It is used by the dry runs
+func (e *Elk) PlaceholderSh(tasks ...string) {
+	_ = e.evalSh(tasks, true)
+}
+
+func (e *Elk) evalSh(tasks []string, placeholders bool) error {
+	eval := e.cache().eval
+	if placeholders {
+		eval = placeholderSh
+	}
+
+	visited := make(map[string]bool)
+
+	var visit func(name string) error
+	visit = func(name string) error {
+		task, exists := e.Tasks[name]
+		if visited[name] || !exists {
+			return nil
+		}
+		visited[name] = true
+
+		if len(task.globalEnvSh) > 0 || len(task.globalVarsSh) > 0 {
+			envSh, varsSh, err := e.evalGlobalSh(placeholders)
+			if err != nil {
+				return err
+			}
+
+			task.Env = maps.MergeMaps(task.Env, pick(envSh, task.globalEnvSh))
+			task.Vars = maps.MergeMaps(task.Vars, pick(varsSh, task.globalVarsSh))
+			task.globalEnvSh, task.globalVarsSh = nil, nil
+			e.Tasks[name] = task
+		}
+
+		if len(task.EnvSh) > 0 || len(task.VarsSh) > 0 {
+			envSh, err := eval("env", task.EnvSh, task.Shell, task.Dir, task.Env)
+			if err != nil {
+				return fmt.Errorf("task '%s': %w", name, err)
+			}
+			task.Env = maps.MergeMaps(task.Env, envSh)
+			task.EnvSh = nil
+
+			varsSh, err := eval("var", task.VarsSh, task.Shell, task.Dir, task.Env)
+			if err != nil {
+				return fmt.Errorf("task '%s': %w", name, err)
+			}
+			task.Vars = maps.MergeMaps(task.Vars, varsSh)
+			task.VarsSh = nil
+
+			e.Tasks[name] = task
+		}
+
+		for _, dep := range task.Deps {
+			err := visit(dep.Name)
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, hook := range task.GetHooks() {
+			err := visit(hook.Task)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	for _, task := range tasks {
+		err := visit(task)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// cache returns the output of the shell commands that were evaluated
+func (e *Elk) cache() shCache {
+	if e.sh == nil {
+		e.sh = make(shCache)
+	}
+
+	return e.sh
+}
+
+// evalGlobalSh returns the values of the global shell commands, the commands
+// run once in the directory of the file
+func (e *Elk) evalGlobalSh(placeholders bool) (map[string]string, map[string]string, error) {
+	if placeholders {
+		envSh, _ := placeholderSh("env", e.EnvSh, e.Shell, "", nil)
+		varsSh, _ := placeholderSh("var", e.VarsSh, e.Shell, "", nil)
+		return envSh, varsSh, nil
+	}
+
+	if e.envSh != nil {
+		return e.envSh, e.varsSh, nil
+	}
+
+	envSh, err := e.cache().eval("env", e.EnvSh, e.Shell, e.getTaskDir(""), e.Env)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	varsSh, err := e.cache().eval("var", e.VarsSh, e.Shell, e.getTaskDir(""), maps.MergeMaps(e.Env, envSh))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	e.envSh, e.varsSh = envSh, varsSh
+	return envSh, varsSh, nil
+}
+
+// inheritedSh returns the names of the values declared as shell commands that
+// are not declared by a task
+func inheritedSh(sh map[string]string, values map[string]string, valuesSh map[string]string) []string {
+	var names []string
+	for name := range sh {
+		_, isValue := values[name]
+		_, isSh := valuesSh[name]
+		if !isValue && !isSh {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// pick returns the values with the given names
+func pick(values map[string]string, names []string) map[string]string {
+	result := make(map[string]string)
+	for _, name := range names {
+		result[name] = values[name]
+	}
+
+	return result
+}
+
 // LoadEnvFile Log to the variable env the values
 func (e *Elk) LoadEnvFile() error {
 	if e.Env == nil {
@@ -118,65 +435,98 @@ func (e *Elk) LoadEnvFile() error {
 	return nil
 }
 
-// HasCircularDependency checks if a task has a circular dependency
+// HasCircularDependency checks if a task has a circular dependency, the error
+// has the tasks of the cycle
 func (e *Elk) HasCircularDependency(name string, visitedNodes ...string) error {
-	if !e.HasTask(name) {
-		return ErrTaskNotFound
+	cycle, err := e.getCycle(name, visitedNodes)
+	if err != nil {
+		return err
 	}
 
-	task := e.Tasks[name]
-
-	if len(task.Deps) == 0 {
-		return nil
+	if len(cycle) > 0 {
+		return fmt.Errorf("%w: %s", ErrCircularDependency, strings.Join(cycle, " -> "))
 	}
 
-	dependencyGraph, err := e.getDependencyGraph(&task)
-	if err != nil {
-		return err
+	return nil
+}
+
+// getCycle returns the tasks of the first circular dependency found from a task
+func (e *Elk) getCycle(name string, visitedNodes []string) ([]string, error) {
+	if !e.HasTask(name) {
+		return nil, ErrTaskNotFound
 	}
 
-	for _, node := range visitedNodes {
+	for i, node := range visitedNodes {
 		if node == name {
-			return ErrCircularDependency
+			return append(append([]string{}, visitedNodes[i:]...), name), nil
 		}
 	}
 
 	visitedNodes = append(visitedNodes, name)
 
-	for _, dep := range dependencyGraph {
-		for _, d := range dep {
-			err = e.HasCircularDependency(d, visitedNodes...)
-			if err != nil {
-				return err
-			}
+	// A dep that does not exist is reported after looking for a cycle in the
+	// rest of the deps
+	var notFound error
+	for _, dep := range e.Tasks[name].Deps {
+		cycle, err := e.getCycle(dep.Name, visitedNodes)
+		if len(cycle) > 0 {
+			return cycle, nil
+		}
+
+		if err != nil && notFound == nil {
+			notFound = err
 		}
 	}
 
-	return nil
+	return nil, notFound
 }
 
-func (e *Elk) getDependencyGraph(task *Task) (map[string][]string, error) {
-	dependencyGraph := make(map[string][]string)
-	deps := task.Deps
-	for _, dep := range deps {
-		// Validate that the dependency is a valid task
-		t, exists := e.Tasks[dep.Name]
-		if !exists {
-			return dependencyGraph, ErrTaskNotFound
+// HasCircularHook checks if a task runs itself as a hook, the deps are
+// followed too because the hook tasks run with their deps
+func (e *Elk) HasCircularHook(name string, visitedNodes ...string) error {
+	if !e.HasTask(name) {
+		return ErrTaskNotFound
+	}
+
+	for i, node := range visitedNodes {
+		if node == name {
+			cycle := append(append([]string{}, visitedNodes[i:]...), name)
+			return fmt.Errorf("%w: %s", ErrCircularDependency, strings.Join(cycle, " -> "))
+		}
+	}
+
+	visitedNodes = append(visitedNodes, name)
+
+	task := e.Tasks[name]
+	for _, hook := range task.GetHooks() {
+		if len(hook.Task) == 0 {
+			continue
+		}
+
+		err := e.HasCircularHook(hook.Task, visitedNodes...)
+		if err != nil {
+			return err
 		}
+	}
 
-		var depsNames []string
-		for _, d := range t.Deps {
-			depsNames = append(depsNames, d.Name)
+	for _, dep := range task.Deps {
+		err := e.HasCircularHook(dep.Name, visitedNodes...)
+		if err != nil {
+			return err
 		}
-		dependencyGraph[dep.Name] = depsNames
 	}
-	return dependencyGraph, nil
+
+	return nil
 }
 
 // FromFile loads an elk object from a file
 func FromFile(filePath string) (*Elk, error) {
-	elk := Elk{}
+	return fromFile(filePath, nil)
+}
+
+// fromFile loads an elk object from a file with the tasks of the files that it
+// includes, parents are the files that include it
+func fromFile(filePath string, parents []string) (*Elk, error) {
 	if _, err := os.Stat(filePath); os.IsNotExist(err) {
 		return nil, fmt.Errorf("path do not exist: '%s'", filePath)
 	}
@@ -186,7 +536,14 @@ func FromFile(filePath string) (*Elk, error) {
 		return nil, err
 	}
 
-	err = yaml.Unmarshal(data, &elk)
+	return fromData(data, filePath, parents)
+}
+
+// fromData loads an elk object from the content of a file, the included files
+// are relative to the path of the file
+func fromData(data []byte, filePath string, parents []string) (*Elk, error) {
+	elk := Elk{}
+	err := yaml.Unmarshal(data, &elk)
 	if err != nil {
 		return nil, err
 	}
@@ -209,6 +566,18 @@ func FromFile(filePath string) (*Elk, error) {
 		elk.Tasks[name] = task
 	}
 
+	path, err := filepath.Abs(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	elk.filePath = path
+
+	err = elk.loadIncludes(path, append(append([]string{}, parents...), path))
+	if err != nil {
+		return nil, err
+	}
+
 	return &elk, nil
 }
 
@@ -1,12 +1,15 @@
 package ox
 
 import (
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"math/rand"
 	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
+	"time"
 
 	"gopkg.in/yaml.v2"
 )
@@ -94,8 +97,8 @@ func TestHasCircularDependency(t *testing.T) {
 		Tasks: map[string]Task{
 			"hello": {
 				Description: "Empty Task",
-				Cmds: []string{
-					"clear",
+				Cmds: []Cmd{
+					{Cmd: "clear"},
 				},
 			},
 			"world": {
@@ -107,8 +110,8 @@ func TestHasCircularDependency(t *testing.T) {
 				Env: map[string]string{
 					"FOO": "BAR",
 				},
-				Cmds: []string{
-					"clear",
+				Cmds: []Cmd{
+					{Cmd: "clear"},
 				},
 			},
 		},
@@ -122,6 +125,32 @@ func TestHasCircularDependency(t *testing.T) {
 	}
 }
 
+func TestHasCircularDependencyPath(t *testing.T) {
+	e := Elk{
+		Tasks: map[string]Task{
+			"hello": {
+				Deps: []Dep{{Name: "world"}},
+			},
+			"world": {
+				Deps: []Dep{{Name: "foo"}},
+			},
+			"foo": {
+				Deps: []Dep{{Name: "world"}},
+			},
+		},
+	}
+
+	err := e.HasCircularDependency("hello")
+	if !errors.Is(err, ErrCircularDependency) {
+		t.Fatalf("The error should be '%v' but it was '%v' instead", ErrCircularDependency, err)
+	}
+
+	expected := "circular dependency: world -> foo -> world"
+	if err.Error() != expected {
+		t.Errorf("The error should be '%s' but it was '%s' instead", expected, err.Error())
+	}
+}
+
 func TestElkBuild(t *testing.T) {
 	err := os.Setenv("BAR", "1")
 	if err != nil {
@@ -274,6 +303,168 @@ func TestElkBuildEnvFileDoNotExistInTask(t *testing.T) {
 	}
 }
 
+func TestElkBuildShell(t *testing.T) {
+	e := Elk{
+		Shell: "bash",
+		Tasks: map[string]Task{
+			"hello": {},
+			"world": {
+				Shell: "sh",
+			},
+		},
+	}
+
+	err := e.Build()
+	if err != nil {
+		t.Error(err)
+	}
+
+	if e.Tasks["hello"].Shell != "bash" {
+		t.Errorf("The task should inherit the shell '%s' but it was '%s' instead", "bash", e.Tasks["hello"].Shell)
+	}
+
+	if e.Tasks["world"].Shell != "sh" {
+		t.Errorf("The task should keep the shell '%s' but it was '%s' instead", "sh", e.Tasks["world"].Shell)
+	}
+
+	e = Elk{
+		Tasks: map[string]Task{
+			"hello": {
+				Cmds: []Cmd{{Cmd: "echo hello", Shell: "fish"}},
+			},
+		},
+	}
+
+	err = e.Build()
+	if err != ErrInvalidShell {
+		t.Error("It should throw an error because the shell is not supported")
+	}
+}
+
+func TestElkBuildGracePeriod(t *testing.T) {
+	e := Elk{
+		GracePeriod: 5 * time.Second,
+		Tasks: map[string]Task{
+			"hello": {},
+			"world": {
+				GracePeriod: time.Second,
+			},
+		},
+	}
+
+	err := e.Build()
+	if err != nil {
+		t.Error(err)
+	}
+
+	if e.Tasks["hello"].GracePeriod != 5*time.Second {
+		t.Errorf("The task should inherit the grace period '%s' but it was '%s' instead", 5*time.Second, e.Tasks["hello"].GracePeriod)
+	}
+
+	if e.Tasks["world"].GracePeriod != time.Second {
+		t.Errorf("The task should keep the grace period '%s' but it was '%s' instead", time.Second, e.Tasks["world"].GracePeriod)
+	}
+
+	e = Elk{
+		Tasks: map[string]Task{
+			"hello": {
+				GracePeriod: -time.Second,
+			},
+		},
+	}
+
+	err = e.Build()
+	if err != ErrInvalidGracePeriod {
+		t.Error("It should throw an error because the grace period is negative")
+	}
+}
+
+func TestElkBuildRelativePaths(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"ox.yml": `
+env_file: ./.env
+tasks:
+  hello:
+    dir: ./foo
+    env_file: ./foo/.env
+    log:
+      out: ./hello.log
+    cmds:
+      - echo hello
+  world:
+    cmds:
+      - echo world
+`,
+		".env":     "FOO=BAR",
+		"foo/.env": "BAR=FOO",
+	})
+	defer os.RemoveAll(dir)
+
+	e, err := FromFile(filepath.Join(dir, "ox.yml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = e.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hello := e.Tasks["hello"]
+	if hello.Dir != filepath.Join(dir, "foo") {
+		t.Errorf("The dir should be '%s' but it was '%s'", filepath.Join(dir, "foo"), hello.Dir)
+	}
+
+	if hello.Log.Out != filepath.Join(dir, "hello.log") {
+		t.Errorf("The log should be '%s' but it was '%s'", filepath.Join(dir, "hello.log"), hello.Log.Out)
+	}
+
+	if hello.Env["FOO"] != "BAR" || hello.Env["BAR"] != "FOO" {
+		t.Errorf("The env variables should be loaded from the env files but they were %v", hello.Env)
+	}
+
+	world := e.Tasks["world"]
+	if world.Dir != dir {
+		t.Errorf("The dir should be '%s' but it was '%s'", dir, world.Dir)
+	}
+}
+
+func TestElkBuildRelativePathsGlobal(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"ox.yml": `
+tasks:
+  hello:
+    dir: ./foo
+    cmds:
+      - echo hello
+  world:
+    cmds:
+      - echo world
+`,
+	})
+	defer os.RemoveAll(dir)
+
+	e, err := FromFile(filepath.Join(dir, "ox.yml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e.SetGlobal(true)
+
+	err = e.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if e.Tasks["hello"].Dir != filepath.Join(dir, "foo") {
+		t.Errorf("The dir should be '%s' but it was '%s'", filepath.Join(dir, "foo"), e.Tasks["hello"].Dir)
+	}
+
+	if len(e.Tasks["world"].Dir) > 0 {
+		t.Errorf("The dir should be empty but it was '%s'", e.Tasks["world"].Dir)
+	}
+}
+
 func TestHasTask(t *testing.T) {
 	e := Elk{
 		Tasks: map[string]Task{
@@ -363,14 +554,14 @@ func TestFromFile(t *testing.T) {
 						Name: "world",
 					},
 				},
-				Cmds: []string{
-					"echo Hello",
+				Cmds: []Cmd{
+					{Cmd: "echo Hello"},
 				},
 			},
 			"world": {
 				Env: make(map[string]string),
-				Cmds: []string{
-					"echo Hello",
+				Cmds: []Cmd{
+					{Cmd: "echo Hello"},
 				},
 				Deps: []Dep{
 					{
@@ -5,3 +5,43 @@ import "errors"
 var ErrCircularDependency = errors.New("circular dependency")
 
 var ErrTaskNotFound = errors.New("task not found")
+
+var ErrInvalidConcurrency = errors.New("concurrency can't be negative")
+
+var ErrInvalidShell = errors.New("shell should be builtin, bash, sh or zsh")
+
+var ErrInvalidGracePeriod = errors.New("grace_period can't be negative")
+
+var ErrInvalidMatrix = errors.New("matrix values can't be empty")
+
+var ErrInvalidArg = errors.New("invalid arg")
+
+var ErrInvalidArgValue = errors.New("invalid arg value")
+
+var ErrUnknownArg = errors.New("unknown arg")
+
+var ErrMissingArg = errors.New("missing required arg")
+
+var ErrUndeclaredVar = errors.New("var is not declared")
+
+var ErrInvalidLimits = errors.New("invalid limits")
+
+var ErrCircularInclude = errors.New("circular include")
+
+var ErrCircularExtends = errors.New("circular extends")
+
+var ErrInvalidLogFormat = errors.New("invalid log format")
+
+var ErrInvalidSources = errors.New("invalid sources")
+
+var ErrEnvFileNotFound = errors.New("env file not found")
+
+var ErrUnknownProperty = errors.New("unknown property")
+
+var ErrMissingProperty = errors.New("missing property")
+
+var ErrInvalidType = errors.New("invalid type")
+
+var ErrInvalidValue = errors.New("invalid value")
+
+var ErrProfileNotFound = errors.New("profile not found")
@@ -0,0 +1,241 @@
+package ox
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jjzcru/elk/pkg/maps"
+)
+
+// Extends are the tasks that a task inherits from, it can be declared as a
+// string when it is a single task
+type Extends []string
+
+// UnmarshalYAML reads the tasks from a string or from a list
+func (e *Extends) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var name string
+	err := unmarshal(&name)
+	if err == nil {
+		*e = Extends{name}
+		return nil
+	}
+
+	var names []string
+	err = unmarshal(&names)
+	if err != nil {
+		return err
+	}
+
+	*e = names
+	return nil
+}
+
+// MarshalYAML writes the tasks as a string if it is a single task
+func (e Extends) MarshalYAML() (interface{}, error) {
+	if len(e) == 1 {
+		return e[0], nil
+	}
+
+	return []string(e), nil
+}
+
+// ResolveExtends replaces each task that extends other tasks with the result
+// of merging them in order and then the task itself
+func (e *Elk) ResolveExtends() error {
+	resolved := make(map[string]bool)
+
+	var resolve func(name string, parents []string) error
+	resolve = func(name string, parents []string) error {
+		if resolved[name] {
+			return nil
+		}
+
+		for _, parent := range parents {
+			if parent == name {
+				return fmt.Errorf("%w: %s", ErrCircularExtends, strings.Join(append(parents, name), " -> "))
+			}
+		}
+
+		task := e.Tasks[name]
+		if len(task.Extends) == 0 {
+			resolved[name] = true
+			return nil
+		}
+
+		var base Task
+		for _, parentName := range task.Extends {
+			if _, ok := e.Tasks[parentName]; !ok {
+				return fmt.Errorf("task '%s' extends '%s': %w", name, parentName, ErrTaskNotFound)
+			}
+
+			err := resolve(parentName, append(append([]string{}, parents...), name))
+			if err != nil {
+				return err
+			}
+
+			base = extendTask(base, e.Tasks[parentName])
+		}
+
+		task = extendTask(base, task)
+		task.Extends = nil
+
+		e.Tasks[name] = task
+		resolved[name] = true
+		return nil
+	}
+
+	for name := range e.Tasks {
+		err := resolve(name, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// extendTask returns the task that results of a task that extends base. The
+// maps are merged, the lists of deps, tags, hooks, preconditions and args are
+// appended and the rest of the values, including the cmds, replace the values
+// of base when they are set
+func extendTask(base Task, task Task) Task {
+	result := task
+
+	result.Env, result.EnvSh = mergeSh(base.Env, base.EnvSh, task.Env, task.EnvSh)
+	result.Vars, result.VarsSh = mergeSh(base.Vars, base.VarsSh, task.Vars, task.VarsSh)
+
+	result.Tags = appendUnique(base.Tags, task.Tags)
+	result.Generates = appendUnique(base.Generates, task.Generates)
+
+	result.Deps = append([]Dep{}, base.Deps...)
+	for _, dep := range task.Deps {
+		result.Deps = removeDep(result.Deps, dep.Name)
+		result.Deps = append(result.Deps, dep)
+	}
+
+	result.Args = append([]Arg{}, base.Args...)
+	for _, arg := range task.Args {
+		result.Args = removeArg(result.Args, arg.Name)
+		result.Args = append(result.Args, arg)
+	}
+
+	result.Before = append(append([]Hook{}, base.Before...), task.Before...)
+	result.After = append(append([]Hook{}, base.After...), task.After...)
+	result.OnFailure = append(append([]Hook{}, base.OnFailure...), task.OnFailure...)
+	result.Finally = append(append([]Hook{}, base.Finally...), task.Finally...)
+	result.Preconditions = append(append([]Precondition{}, base.Preconditions...), task.Preconditions...)
+
+	if len(task.Cmds) == 0 {
+		result.Cmds = base.Cmds
+	}
+
+	if len(task.Title) == 0 {
+		result.Title = base.Title
+	}
+
+	if len(task.Description) == 0 {
+		result.Description = base.Description
+	}
+
+	if len(task.EnvFile) == 0 {
+		result.EnvFile = base.EnvFile
+	}
+
+	if len(task.Dir) == 0 {
+		result.Dir = base.Dir
+	}
+
+	if len(task.Log.Out) == 0 && len(task.Log.Err) == 0 && len(task.Log.Format) == 0 {
+		result.Log = base.Log
+	}
+
+	if len(task.Sources) == 0 {
+		result.Sources = base.Sources
+	}
+
+	if len(task.If) == 0 {
+		result.If = base.If
+	}
+
+	if len(task.Shell) == 0 {
+		result.Shell = base.Shell
+	}
+
+	if task.Retry == nil {
+		result.Retry = base.Retry
+	}
+
+	if task.Timeout == 0 {
+		result.Timeout = base.Timeout
+	}
+
+	if task.GracePeriod == 0 {
+		result.GracePeriod = base.GracePeriod
+	}
+
+	if len(task.Matrix) == 0 {
+		result.Matrix = base.Matrix
+	}
+
+	if task.Limits == nil {
+		result.Limits = base.Limits
+	}
+
+	result.IgnoreError = base.IgnoreError || task.IgnoreError
+	result.Interactive = base.Interactive || task.Interactive
+
+	return result
+}
+
+// mergeSh merges the values of a map and the values declared as shell
+// commands of two tasks, a value of the task replaces the one of base even
+// if one of them is a shell command
+func mergeSh(baseValues, baseSh, values, sh map[string]string) (map[string]string, map[string]string) {
+	resultValues := maps.MergeMaps(baseValues, values)
+	resultSh := maps.MergeMaps(baseSh, sh)
+
+	for k := range values {
+		delete(resultSh, k)
+	}
+
+	for k := range sh {
+		delete(resultValues, k)
+	}
+
+	return resultValues, resultSh
+}
+
+func appendUnique(base []string, values []string) []string {
+	var result []string
+	exists := make(map[string]bool)
+	for _, value := range append(append([]string{}, base...), values...) {
+		if !exists[value] {
+			exists[value] = true
+			result = append(result, value)
+		}
+	}
+
+	return result
+}
+
+func removeDep(deps []Dep, name string) []Dep {
+	var result []Dep
+	for _, dep := range deps {
+		if dep.Name != name {
+			result = append(result, dep)
+		}
+	}
+
+	return result
+}
+
+func removeArg(args []Arg, name string) []Arg {
+	var result []Arg
+	for _, arg := range args {
+		if arg.Name != name {
+			result = append(result, arg)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,136 @@
+package ox
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+func TestElkResolveExtends(t *testing.T) {
+	content := `
+tasks:
+  base:
+    description: Deploy the app
+    dir: ./deploy
+    env:
+      REGION: us-east-1
+      TOKEN:
+        sh: cat token
+    vars:
+      replicas: "1"
+    log:
+      out: ./deploy.log
+    deps:
+      - name: build
+    finally:
+      - echo done
+    cmds:
+      - ./deploy.sh {{.env}} {{.replicas}}
+  build:
+    cmds:
+      - go build
+  notify:
+    deps:
+      - name: build
+        detached: true
+    after:
+      - ./notify.sh
+  prod:
+    extends: [base, notify]
+    env:
+      TOKEN: secret
+    vars:
+      env: prod
+      replicas: "3"
+  staging:
+    extends: prod
+    vars:
+      env: staging
+    cmds:
+      - ./deploy-staging.sh
+`
+	e := Elk{}
+	err := yaml.Unmarshal([]byte(content), &e)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	err = e.ResolveExtends()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	prod := e.Tasks["prod"]
+	if prod.Description != "Deploy the app" || prod.Dir != "./deploy" || prod.Log.Out != "./deploy.log" {
+		t.Errorf("The task should inherit the description, dir and log but it has '%s', '%s' and '%s'", prod.Description, prod.Dir, prod.Log.Out)
+	}
+
+	expectedEnv := map[string]string{"REGION": "us-east-1", "TOKEN": "secret"}
+	if !reflect.DeepEqual(prod.Env, expectedEnv) || len(prod.EnvSh) != 0 {
+		t.Errorf("The env should be %v but it was %v and %v instead", expectedEnv, prod.Env, prod.EnvSh)
+	}
+
+	expectedVars := map[string]string{"env": "prod", "replicas": "3"}
+	if !reflect.DeepEqual(prod.Vars, expectedVars) {
+		t.Errorf("The vars should be %v but it was %v instead", expectedVars, prod.Vars)
+	}
+
+	if len(prod.Deps) != 1 || prod.Deps[0].Name != "build" || !prod.Deps[0].Detached {
+		t.Errorf("The task should depend on '%s' as declared by the last task that it extends, but it has %v", "build", prod.Deps)
+	}
+
+	if len(prod.Finally) != 1 || len(prod.After) != 1 {
+		t.Errorf("The task should inherit the hooks")
+	}
+
+	if len(prod.Cmds) != 1 || prod.Cmds[0].Cmd != "./deploy.sh {{.env}} {{.replicas}}" {
+		t.Errorf("The task should inherit the cmds but it has %v", prod.Cmds)
+	}
+
+	staging := e.Tasks["staging"]
+	if staging.Vars["env"] != "staging" || staging.Vars["replicas"] != "3" {
+		t.Errorf("The vars should be inherited from the task that it extends but they were %v", staging.Vars)
+	}
+
+	if len(staging.Cmds) != 1 || staging.Cmds[0].Cmd != "./deploy-staging.sh" {
+		t.Errorf("The cmds of the task should replace the ones that it inherits but it has %v", staging.Cmds)
+	}
+
+	if len(prod.Extends) != 0 || len(staging.Extends) != 0 {
+		t.Error("The tasks should not extend other tasks once they are resolved")
+	}
+}
+
+func TestElkResolveExtendsErrors(t *testing.T) {
+	tests := []struct {
+		tasks map[string]Task
+		err   error
+	}{
+		{
+			map[string]Task{
+				"a": {Extends: Extends{"b"}},
+				"b": {Extends: Extends{"c"}},
+				"c": {Extends: Extends{"a"}},
+			},
+			ErrCircularExtends,
+		},
+		{
+			map[string]Task{
+				"a": {Extends: Extends{"b"}},
+			},
+			ErrTaskNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		e := Elk{Tasks: test.tasks}
+		err := e.ResolveExtends()
+		if !errors.Is(err, test.err) {
+			t.Errorf("The error should be '%v' but it was '%v' instead", test.err, err)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package ox
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// funcs returns the functions available in the templates of the commands
+func (v *Vars) funcs() template.FuncMap {
+	return template.FuncMap{
+		"env":        v.env,
+		"default":    defaultValue,
+		"upper":      strings.ToUpper,
+		"lower":      strings.ToLower,
+		"join":       join,
+		"split":      split,
+		"os":         func() string { return runtime.GOOS },
+		"arch":       func() string { return runtime.GOARCH },
+		"now":        time.Now,
+		"date":       date,
+		"sha256":     v.sha256,
+		"readFile":   v.readFile,
+		"toJson":     toJSON,
+		"shellQuote": shellQuote,
+	}
+}
+
+// env returns the value of an env variable of the task, or of the system
+// if the task does not declare it
+func (v *Vars) env(name string) string {
+	if value, ok := v.Env[name]; ok {
+		return value
+	}
+
+	return os.Getenv(name)
+}
+
+// path returns a path relative to the directory of the task
+func (v *Vars) path(path string) string {
+	if filepath.IsAbs(path) || len(v.Dir) == 0 {
+		return path
+	}
+
+	return filepath.Join(v.Dir, path)
+}
+
+// sha256 returns the hex encoded sha256 of the content of a file
+func (v *Vars) sha256(path string) (string, error) {
+	f, err := os.Open(v.path(path))
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	_, err = io.Copy(h, f)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// readFile returns the content of a file without the trailing new lines
+func (v *Vars) readFile(path string) (string, error) {
+	content, err := ioutil.ReadFile(v.path(path))
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(string(content), "\r\n"), nil
+}
+
+// defaultValue returns value or fallback if value is empty, it is used as
+// {{.value | default "fallback"}}
+func defaultValue(fallback interface{}, value interface{}) interface{} {
+	if value == nil || fmt.Sprintf("%v", value) == "" {
+		return fallback
+	}
+
+	return value
+}
+
+// join returns the elements of a list joined by sep, it is used as
+// {{.list | join ","}}
+func join(sep string, list interface{}) (string, error) {
+	switch values := list.(type) {
+	case []string:
+		return strings.Join(values, sep), nil
+	case []interface{}:
+		var elements []string
+		for _, value := range values {
+			elements = append(elements, fmt.Sprintf("%v", value))
+		}
+		return strings.Join(elements, sep), nil
+	case string:
+		return values, nil
+	}
+
+	return "", fmt.Errorf("join can not be used with %T", list)
+}
+
+// split returns the parts of s separated by sep, it is used as
+// {{.value | split ","}}
+func split(sep string, s string) []string {
+	return strings.Split(s, sep)
+}
+
+// date returns a time with a format of the time package, like 2006-01-02
+func date(layout string, t time.Time) string {
+	return t.Format(layout)
+}
+
+func toJSON(value interface{}) (string, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+// shellQuote returns s quoted to be used as a single argument of a command
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,67 @@
+package ox
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestVarsFuncs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "elk-funcs-")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	err = ioutil.WriteFile(filepath.Join(dir, "version"), []byte("1.0.0\n"), 0644)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	vars := map[string]string{
+		"name":  "Elk",
+		"empty": "",
+		"list":  "a,b,c",
+		"msg":   "it's",
+	}
+
+	tests := []struct {
+		cmd      string
+		expected string
+	}{
+		{`{{env "STAGE"}}`, "dev"},
+		{`{{.empty | default "none"}}`, "none"},
+		{`{{.name | default "none"}}`, "Elk"},
+		{`{{upper .name}} {{lower .name}}`, "ELK elk"},
+		{`{{.list | split "," | join " "}}`, "a b c"},
+		{`{{os}}/{{arch}}`, fmt.Sprintf("%s/%s", runtime.GOOS, runtime.GOARCH)},
+		{`{{now | date "2006" | len}}`, "4"},
+		{`{{sha256 "version"}}`, "59854984853104df5c353e2f681a15fc7924742f9a2e468c29af248dce45ce03"},
+		{`{{readFile "version"}}`, "1.0.0"},
+		{`{{split "," .list | toJson}}`, `["a","b","c"]`},
+		{`echo {{shellQuote .msg}}`, `echo 'it'\''s'`},
+	}
+
+	for _, test := range tests {
+		v := Vars{
+			Map: vars,
+			Env: map[string]string{"STAGE": "dev"},
+			Dir: dir,
+		}
+
+		cmd, err := v.Process(test.cmd)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if cmd != test.expected {
+			t.Errorf("The command '%s' should be '%s' but it was '%s' instead", test.cmd, test.expected, cmd)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package ox
+
+// Hook is a command or a task that runs at a specific moment of the lifecycle
+// of a task, it can be declared as a string when is a command
+type Hook struct {
+	Cmd  string `yaml:"cmd,omitempty"`
+	Task string `yaml:"task,omitempty"`
+}
+
+// UnmarshalYAML reads a hook from a string or from an object
+func (h *Hook) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var cmd string
+	err := unmarshal(&cmd)
+	if err == nil {
+		h.Cmd = cmd
+		return nil
+	}
+
+	type plain Hook
+	return unmarshal((*plain)(h))
+}
+
+// MarshalYAML writes the hook as a string if it is a command
+func (h Hook) MarshalYAML() (interface{}, error) {
+	if len(h.Task) == 0 {
+		return h.Cmd, nil
+	}
+
+	type plain Hook
+	return plain(h), nil
+}
@@ -0,0 +1,72 @@
+package ox
+
+import (
+	"errors"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+func TestHookUnmarshalYAML(t *testing.T) {
+	content := `
+finally:
+  - docker-compose down
+  - task: release-lock
+`
+	task := Task{}
+	err := yaml.Unmarshal([]byte(content), &task)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if len(task.Finally) != 2 {
+		t.Errorf("The task should have %d hooks but it has %d instead", 2, len(task.Finally))
+		return
+	}
+
+	if task.Finally[0].Cmd != "docker-compose down" {
+		t.Errorf("The hook command should be '%s' but it was '%s' instead", "docker-compose down", task.Finally[0].Cmd)
+	}
+
+	if task.Finally[1].Task != "release-lock" {
+		t.Errorf("The hook task should be '%s' but it was '%s' instead", "release-lock", task.Finally[1].Task)
+	}
+}
+
+func TestHasCircularHook(t *testing.T) {
+	e := Elk{
+		Tasks: map[string]Task{
+			"hello": {
+				Finally: []Hook{{Task: "world"}},
+			},
+			"world": {
+				Before: []Hook{{Task: "hello"}},
+			},
+			"foo": {
+				After: []Hook{{Task: "bar"}},
+			},
+			"build": {
+				Deps: []Dep{{Name: "clean"}},
+			},
+			"clean": {
+				After: []Hook{{Task: "build"}},
+			},
+		},
+	}
+
+	err := e.HasCircularHook("hello")
+	if !errors.Is(err, ErrCircularDependency) {
+		t.Error("Should throw an error because the hooks are circular")
+	}
+
+	err = e.HasCircularHook("build")
+	if !errors.Is(err, ErrCircularDependency) {
+		t.Error("Should throw an error because the hook runs a task that depends on it")
+	}
+
+	err = e.HasCircularHook("foo")
+	if err != ErrTaskNotFound {
+		t.Error("Should throw an error because the hook task do not exist")
+	}
+}
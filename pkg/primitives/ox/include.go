@@ -0,0 +1,176 @@
+package ox
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/jjzcru/elk/pkg/file"
+	"github.com/jjzcru/elk/pkg/maps"
+)
+
+// NamespaceSeparator separates the namespace of an included file from the
+// name of its tasks, like docker:build
+const NamespaceSeparator = ":"
+
+// Include is another ox file whose tasks are added to the file, it can be
+// declared as a string when it only has the path of the file
+type Include struct {
+	File      string `yaml:"file"`
+	Namespace string `yaml:"namespace,omitempty"`
+
+	// Dir is the directory used for the relative dir, env_file and log of the
+	// tasks of the file, by default it is the directory of the file
+	Dir string `yaml:"dir,omitempty"`
+}
+
+// UnmarshalYAML reads an include from a string or from an object
+func (i *Include) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var path string
+	err := unmarshal(&path)
+	if err == nil {
+		i.File = path
+		return nil
+	}
+
+	type plain Include
+	return unmarshal((*plain)(i))
+}
+
+// MarshalYAML writes the include as a string if it only has the path
+func (i Include) MarshalYAML() (interface{}, error) {
+	if len(i.Namespace) == 0 && len(i.Dir) == 0 {
+		return i.File, nil
+	}
+
+	type plain Include
+	return plain(i), nil
+}
+
+// loadIncludes adds the tasks of the files included by an ox file, parents
+// are the files that include it and are used to detect include cycles
+func (e *Elk) loadIncludes(filePath string, parents []string) error {
+	dir := filepath.Dir(filePath)
+
+	for _, include := range e.Include {
+		if len(include.File) == 0 {
+			return fmt.Errorf("an include of '%s' does not have a file", filePath)
+		}
+
+		path := getPath(dir, include.File)
+		for _, parent := range parents {
+			if parent == path {
+				return fmt.Errorf("%w: %s", ErrCircularInclude, strings.Join(append(parents, path), " -> "))
+			}
+		}
+
+		included, err := fromFile(path, parents)
+		if err != nil {
+			return err
+		}
+
+		baseDir := filepath.Dir(path)
+		if len(include.Dir) > 0 {
+			baseDir = getPath(dir, include.Dir)
+		}
+
+		err = e.addIncluded(include, included, baseDir)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// addIncluded adds the tasks of an included file with its namespace, the
+// deps and hooks between its tasks use the name with the namespace
+func (e *Elk) addIncluded(include Include, included *Elk, baseDir string) error {
+	name := func(task string) string {
+		if _, ok := included.Tasks[task]; !ok || len(include.Namespace) == 0 {
+			return task
+		}
+
+		return include.Namespace + NamespaceSeparator + task
+	}
+
+	var env map[string]string
+	if len(included.EnvFile) > 0 {
+		var err error
+		env, err = file.GetEnvFromFile(getPath(baseDir, included.EnvFile))
+		if err != nil {
+			return err
+		}
+	}
+	env = maps.MergeMaps(env, included.Env)
+
+	if e.included == nil {
+		e.included = make(map[string]bool)
+	}
+
+	for taskName, task := range included.Tasks {
+		fullName := name(taskName)
+		if _, exists := e.Tasks[fullName]; exists {
+			return fmt.Errorf("task '%s' from '%s' already exists", fullName, include.File)
+		}
+
+		task.Env, task.EnvSh = mergeSh(env, included.EnvSh, task.Env, task.EnvSh)
+		task.Vars, task.VarsSh = mergeSh(included.Vars, included.VarsSh, task.Vars, task.VarsSh)
+
+		if len(task.Shell) == 0 {
+			task.Shell = included.Shell
+		}
+
+		if task.GracePeriod == 0 {
+			task.GracePeriod = included.GracePeriod
+		}
+
+		task.Dir = getPath(baseDir, task.Dir)
+		if len(task.EnvFile) > 0 {
+			task.EnvFile = getPath(baseDir, task.EnvFile)
+		}
+
+		if len(task.Log.Out) > 0 {
+			task.Log.Out = getPath(baseDir, task.Log.Out)
+		}
+
+		if len(task.Log.Err) > 0 {
+			task.Log.Err = getPath(baseDir, task.Log.Err)
+		}
+
+		var deps []Dep
+		for _, dep := range task.Deps {
+			dep.Name = name(dep.Name)
+			deps = append(deps, dep)
+		}
+		task.Deps = deps
+
+		var extends Extends
+		for _, parent := range task.Extends {
+			extends = append(extends, name(parent))
+		}
+		task.Extends = extends
+
+		for _, hooks := range [][]Hook{task.Before, task.After, task.OnFailure, task.Finally} {
+			for i := range hooks {
+				if len(hooks[i].Task) > 0 {
+					hooks[i].Task = name(hooks[i].Task)
+				}
+			}
+		}
+
+		e.Tasks[fullName] = task
+		e.included[fullName] = true
+	}
+
+	return nil
+}
+
+// getPath returns path relative to dir if it is not absolute
+func getPath(dir string, path string) string {
+	if filepath.IsAbs(path) {
+		return filepath.Clean(path)
+	}
+
+	return filepath.Join(dir, path)
+}
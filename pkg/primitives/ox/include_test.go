@@ -0,0 +1,241 @@
+package ox
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	dir, err := ioutil.TempDir("", "elk-include-")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		err = os.MkdirAll(filepath.Dir(path), os.ModePerm)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = ioutil.WriteFile(path, []byte(content), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return dir
+}
+
+func TestFromFileInclude(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"ox.yml": `
+include:
+  - file: docker/ox.yml
+    namespace: docker
+  - lint.yml
+tasks:
+  deploy:
+    deps:
+      - name: docker:push
+    cmds:
+      - echo deploy
+`,
+		"lint.yml": `
+tasks:
+  lint:
+    cmds:
+      - golint ./...
+`,
+		"docker/ox.yml": `
+env_file: docker.env
+vars:
+  image: elk
+tasks:
+  build:
+    cmds:
+      - docker build -t {{.image}} .
+  push:
+    dir: ./image
+    env_file: push.env
+    deps:
+      - name: build
+    finally:
+      - task: build
+    cmds:
+      - docker push {{.image}}
+`,
+		"docker/docker.env": "REGISTRY=docker.io\n",
+	})
+	defer os.RemoveAll(dir)
+
+	e, err := FromFile(filepath.Join(dir, "ox.yml"))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	for _, name := range []string{"deploy", "lint", "docker:build", "docker:push"} {
+		if !e.HasTask(name) {
+			t.Errorf("The task '%s' should exist", name)
+		}
+	}
+
+	push := e.Tasks["docker:push"]
+	if push.Deps[0].Name != "docker:build" || push.Finally[0].Task != "docker:build" {
+		t.Errorf("The deps and hooks of '%s' should use the namespace", "docker:push")
+	}
+
+	if push.Dir != filepath.Join(dir, "docker", "image") {
+		t.Errorf("The dir should be '%s' but it was '%s' instead", filepath.Join(dir, "docker", "image"), push.Dir)
+	}
+
+	if push.EnvFile != filepath.Join(dir, "docker", "push.env") {
+		t.Errorf("The env_file should be '%s' but it was '%s' instead", filepath.Join(dir, "docker", "push.env"), push.EnvFile)
+	}
+
+	build := e.Tasks["docker:build"]
+	if build.Dir != filepath.Join(dir, "docker") {
+		t.Errorf("The dir should be '%s' but it was '%s' instead", filepath.Join(dir, "docker"), build.Dir)
+	}
+
+	if build.Env["REGISTRY"] != "docker.io" || build.Vars["image"] != "elk" {
+		t.Errorf("The task should have the env and vars of its file but it has %v and %v", build.Env, build.Vars)
+	}
+
+	err = e.HasCircularDependency("deploy")
+	if err != nil {
+		t.Error(err)
+	}
+
+	data, err := yaml.Marshal(e)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	saved := Elk{}
+	err = yaml.Unmarshal(data, &saved)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if len(saved.Tasks) != 1 || len(saved.Include) != 2 {
+		t.Errorf("The file should be saved without the included tasks but it has %d tasks", len(saved.Tasks))
+	}
+}
+
+func TestFromFileIncludeSh(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"ox.yml": `
+include:
+  - tools.yml
+`,
+		"tools.yml": `
+env:
+  TARGET:
+    sh: echo global
+vars:
+  name:
+    sh: echo global
+tasks:
+  global:
+    cmds:
+      - echo {{.name}}
+  local:
+    env:
+      TARGET: local
+    vars:
+      name: local
+    cmds:
+      - echo {{.name}}
+`,
+	})
+	defer os.RemoveAll(dir)
+
+	e, err := FromFile(filepath.Join(dir, "ox.yml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = e.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = e.EvalSh("global", "local")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]string{
+		"global": "global",
+		"local":  "local",
+	}
+
+	for name, value := range expected {
+		task := e.Tasks[name]
+		if task.Env["TARGET"] != value || task.Vars["name"] != value {
+			t.Errorf("The task '%s' should have '%s' in its env and vars but it has %v and %v", name, value, task.Env["TARGET"], task.Vars["name"])
+		}
+	}
+}
+
+func TestFromFileIncludeCycle(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"ox.yml": `
+include:
+  - a/ox.yml
+tasks:
+  test:
+    cmds:
+      - echo test
+`,
+		"a/ox.yml": `
+include:
+  - ../b.yml
+`,
+		"b.yml": `
+include:
+  - file: a/ox.yml
+    namespace: a
+`,
+	})
+	defer os.RemoveAll(dir)
+
+	_, err := FromFile(filepath.Join(dir, "ox.yml"))
+	if !errors.Is(err, ErrCircularInclude) {
+		t.Errorf("The error should be '%v' but it was '%v' instead", ErrCircularInclude, err)
+	}
+}
+
+func TestFromFileIncludeDuplicated(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"ox.yml": `
+include:
+  - test.yml
+tasks:
+  test:
+    cmds:
+      - echo test
+`,
+		"test.yml": `
+tasks:
+  test:
+    cmds:
+      - echo included
+`,
+	})
+	defer os.RemoveAll(dir)
+
+	_, err := FromFile(filepath.Join(dir, "ox.yml"))
+	if err == nil {
+		t.Error("It should return an error because the task already exists")
+	}
+}
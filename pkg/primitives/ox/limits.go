@@ -0,0 +1,108 @@
+package ox
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jjzcru/elk/pkg/shell"
+)
+
+// Limits are the resources that the commands of a task can use, they are
+// only applied on Linux
+type Limits struct {
+	Memory     string        `yaml:"memory,omitempty"`
+	CPU        time.Duration `yaml:"cpu,omitempty"`
+	OpenFiles  uint64        `yaml:"open_files,omitempty"`
+	Processes  uint64        `yaml:"processes,omitempty"`
+	Nice       int           `yaml:"nice,omitempty"`
+	IOClass    string        `yaml:"io_class,omitempty"`
+	IOPriority int           `yaml:"io_priority,omitempty"`
+}
+
+var memoryUnits = map[string]uint64{
+	"":  1,
+	"k": 1 << 10,
+	"m": 1 << 20,
+	"g": 1 << 30,
+	"t": 1 << 40,
+}
+
+// GetMemory returns the memory limit in bytes, it can be written with a unit
+// like 512M or 2G
+func (l *Limits) GetMemory() (uint64, error) {
+	memory := strings.ToLower(strings.TrimSpace(l.Memory))
+	if len(memory) == 0 {
+		return 0, nil
+	}
+
+	memory = strings.TrimSuffix(strings.TrimSuffix(memory, "b"), "i")
+
+	unit := ""
+	if len(memory) > 0 && memoryUnits[memory[len(memory)-1:]] > 1 {
+		unit = memory[len(memory)-1:]
+		memory = memory[:len(memory)-1]
+	}
+
+	value, err := strconv.ParseUint(strings.TrimSpace(memory), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: memory '%s' should be a size like 512M or 2G", ErrInvalidLimits, l.Memory)
+	}
+
+	return value * memoryUnits[unit], nil
+}
+
+// Validate checks that the values of the limits are valid
+func (l *Limits) Validate() error {
+	if l == nil {
+		return nil
+	}
+
+	_, err := l.GetMemory()
+	if err != nil {
+		return err
+	}
+
+	if l.CPU < 0 {
+		return fmt.Errorf("%w: cpu can't be negative", ErrInvalidLimits)
+	}
+
+	if l.Nice < -20 || l.Nice > 19 {
+		return fmt.Errorf("%w: nice should be between -20 and 19", ErrInvalidLimits)
+	}
+
+	switch l.IOClass {
+	case "", shell.IORealtime, shell.IOBestEffort, shell.IOIdle:
+	default:
+		return fmt.Errorf("%w: io_class should be realtime, best-effort or idle", ErrInvalidLimits)
+	}
+
+	if l.IOPriority < 0 || l.IOPriority > 7 {
+		return fmt.Errorf("%w: io_priority should be between 0 and 7", ErrInvalidLimits)
+	}
+
+	return nil
+}
+
+// GetShellLimits returns the limits that are applied to the commands
+func (l *Limits) GetShellLimits() (*shell.Limits, error) {
+	if l == nil {
+		return nil, nil
+	}
+
+	memory, err := l.GetMemory()
+	if err != nil {
+		return nil, err
+	}
+
+	return &shell.Limits{
+		Memory:     memory,
+		CPU:        l.CPU,
+		OpenFiles:  l.OpenFiles,
+		Processes:  l.Processes,
+		Nice:       l.Nice,
+		IOClass:    l.IOClass,
+		IOPriority: l.IOPriority,
+	}, nil
+}
@@ -0,0 +1,57 @@
+package ox
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLimitsGetMemory(t *testing.T) {
+	tests := map[string]uint64{
+		"":       0,
+		"1024":   1024,
+		"512K":   512 << 10,
+		"512M":   512 << 20,
+		"512MB":  512 << 20,
+		"512MiB": 512 << 20,
+		"2g":     2 << 30,
+		"1T":     1 << 40,
+	}
+
+	for memory, expected := range tests {
+		limits := Limits{Memory: memory}
+		value, err := limits.GetMemory()
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if value != expected {
+			t.Errorf("The memory of '%s' should be %d but it was %d instead", memory, expected, value)
+		}
+	}
+}
+
+func TestLimitsValidate(t *testing.T) {
+	tests := []Limits{
+		{Memory: "lots"},
+		{Memory: "M"},
+		{Memory: "B"},
+		{CPU: -1},
+		{Nice: 20},
+		{IOClass: "fast"},
+		{IOClass: "best-effort", IOPriority: 8},
+	}
+
+	for _, limits := range tests {
+		err := limits.Validate()
+		if !errors.Is(err, ErrInvalidLimits) {
+			t.Errorf("The error should be '%v' but it was '%v' instead", ErrInvalidLimits, err)
+		}
+	}
+
+	limits := Limits{Memory: "1G", Nice: 10, IOClass: "idle"}
+	err := limits.Validate()
+	if err != nil {
+		t.Error(err)
+	}
+}
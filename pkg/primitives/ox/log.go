@@ -0,0 +1,47 @@
+package ox
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// logFormats are the timestamp formats of a log by name
+var logFormats = map[string]string{
+	"ANSIC":       time.ANSIC,
+	"ansic":       time.ANSIC,
+	"UnixDate":    time.UnixDate,
+	"unixdate":    time.UnixDate,
+	"RubyDate":    time.RubyDate,
+	"rubydate":    time.RubyDate,
+	"RFC822":      time.RFC822,
+	"RFC822Z":     time.RFC822Z,
+	"RFC850":      time.RFC850,
+	"RFC1123":     time.RFC1123,
+	"RFC1123Z":    time.RFC1123Z,
+	"RFC3339":     time.RFC3339,
+	"RFC3339Nano": time.RFC3339Nano,
+	"Kitchen":     time.Kitchen,
+	"kitchen":     time.Kitchen,
+}
+
+// GetLogFormat returns the time layout of a log format
+func GetLogFormat(format string) (string, error) {
+	layout, ok := logFormats[format]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrInvalidLogFormat, format)
+	}
+
+	return layout, nil
+}
+
+// getLogFormatNames returns the names of the log formats sorted
+func getLogFormatNames() []string {
+	var names []string
+	for name := range logFormats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
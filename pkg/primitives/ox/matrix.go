@@ -0,0 +1,100 @@
+package ox
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jjzcru/elk/pkg/maps"
+)
+
+// ExpandMatrix replaces each task that declares a matrix with an instance for
+// each combination of its values, named like test[go=1.21,os=linux]. The task
+// keeps its name and args and depends on all its instances, so running it runs
+// all the combinations in parallel
+func (e *Elk) ExpandMatrix() error {
+	for name, task := range e.Tasks {
+		if len(task.Matrix) == 0 {
+			continue
+		}
+
+		combinations, err := getCombinations(task.Matrix)
+		if err != nil {
+			return err
+		}
+
+		var deps []Dep
+		var instances []string
+		for _, combination := range combinations {
+			instanceName := GetInstanceName(name, combination)
+			if _, exists := e.Tasks[instanceName]; exists {
+				return fmt.Errorf("task '%s' from the matrix of '%s' already exists", instanceName, name)
+			}
+
+			instance := task
+			instance.Matrix = nil
+			instance.Vars = maps.MergeMaps(task.Vars, combination)
+			e.Tasks[instanceName] = instance
+
+			deps = append(deps, Dep{Name: instanceName})
+			instances = append(instances, instanceName)
+		}
+
+		e.Tasks[name] = Task{
+			Title:       task.Title,
+			Tags:        task.Tags,
+			Description: task.Description,
+			Sources:     task.Sources,
+			Args:        task.Args,
+			Deps:        deps,
+			instances:   instances,
+		}
+	}
+
+	return nil
+}
+
+// GetInstanceName returns the name of the instance of a task for a
+// combination of the values of its matrix
+func GetInstanceName(name string, combination map[string]string) string {
+	var keys []string
+	for k := range combination {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var labels []string
+	for _, k := range keys {
+		labels = append(labels, fmt.Sprintf("%s=%s", k, combination[k]))
+	}
+
+	return fmt.Sprintf("%s[%s]", name, strings.Join(labels, ","))
+}
+
+// getCombinations returns all the combinations of the values of a matrix, the
+// keys are sorted and the values keep the order in which they are declared
+func getCombinations(matrix map[string][]string) ([]map[string]string, error) {
+	var keys []string
+	for k, values := range matrix {
+		if len(values) == 0 {
+			return nil, ErrInvalidMatrix
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	combinations := []map[string]string{{}}
+	for _, k := range keys {
+		var next []map[string]string
+		for _, combination := range combinations {
+			for _, value := range matrix[k] {
+				c := maps.CopyMap(combination)
+				c[k] = value
+				next = append(next, c)
+			}
+		}
+		combinations = next
+	}
+
+	return combinations, nil
+}
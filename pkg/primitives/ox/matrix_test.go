@@ -0,0 +1,135 @@
+package ox
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+func TestElkExpandMatrix(t *testing.T) {
+	content := `
+vars:
+  name: elk
+tasks:
+  test:
+    description: Run the tests
+    matrix:
+      go: [1.20, 1.21]
+      os: [linux, darwin]
+    cmds:
+      - echo {{.go}} {{.os}}
+`
+	e := Elk{}
+	err := yaml.Unmarshal([]byte(content), &e)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	err = e.ExpandMatrix()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	expected := []string{
+		"test[go=1.20,os=linux]",
+		"test[go=1.20,os=darwin]",
+		"test[go=1.21,os=linux]",
+		"test[go=1.21,os=darwin]",
+	}
+
+	task := e.Tasks["test"]
+	if len(task.Deps) != len(expected) {
+		t.Errorf("The task should have %d deps but it has %d instead", len(expected), len(task.Deps))
+		return
+	}
+
+	if len(task.Cmds) > 0 {
+		t.Errorf("The task should not have cmds but it has %d instead", len(task.Cmds))
+	}
+
+	for i, name := range expected {
+		if task.Deps[i].Name != name {
+			t.Errorf("The dep should be '%s' but it was '%s' instead", name, task.Deps[i].Name)
+		}
+
+		instance, exists := e.Tasks[name]
+		if !exists {
+			t.Errorf("The task '%s' should exist", name)
+			continue
+		}
+
+		if len(instance.Matrix) > 0 {
+			t.Errorf("The task '%s' should not have a matrix", name)
+		}
+
+		if len(instance.Cmds) != 1 {
+			t.Errorf("The task '%s' should have 1 cmd but it has %d instead", name, len(instance.Cmds))
+		}
+	}
+
+	instance := e.Tasks["test[go=1.20,os=darwin]"]
+	if instance.Vars["go"] != "1.20" || instance.Vars["os"] != "darwin" {
+		t.Errorf("The vars should be go=1.20 and os=darwin but they were go=%s and os=%s instead", instance.Vars["go"], instance.Vars["os"])
+	}
+}
+
+func TestElkExpandMatrixEmptyValues(t *testing.T) {
+	e := Elk{
+		Tasks: map[string]Task{
+			"test": {
+				Matrix: map[string][]string{"os": {}},
+			},
+		},
+	}
+
+	err := e.ExpandMatrix()
+	if err != ErrInvalidMatrix {
+		t.Errorf("The error should be '%v' but it was '%v' instead", ErrInvalidMatrix, err)
+	}
+}
+
+func TestElkExpandMatrixDuplicated(t *testing.T) {
+	e := Elk{
+		Tasks: map[string]Task{
+			"test": {
+				Matrix: map[string][]string{"os": {"linux"}},
+			},
+			"test[os=linux]": {},
+		},
+	}
+
+	err := e.ExpandMatrix()
+	if err == nil {
+		t.Error("It should return an error because the instance already exists")
+	}
+}
+
+func TestElkExpandMatrixArgs(t *testing.T) {
+	e := Elk{
+		Tasks: map[string]Task{
+			"test": {
+				Matrix: map[string][]string{"os": {"linux", "darwin"}},
+				Args:   []Arg{{Name: "pkg", Default: "./..."}, {Name: "verbose", Type: ArgBool}},
+			},
+		},
+	}
+
+	err := e.ExpandMatrix()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = e.SetArgs([]string{"test"}, map[string]string{"verbose": "true"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"test[os=linux]", "test[os=darwin]"} {
+		vars := e.Tasks[name].Vars
+		if vars["verbose"] != "true" || vars["pkg"] != "./..." {
+			t.Errorf("The task '%s' should have the args of the task but it has the vars %v", name, vars)
+		}
+	}
+}
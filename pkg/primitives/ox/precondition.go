@@ -0,0 +1,31 @@
+package ox
+
+// Precondition is a shell check that must succeed for a task to run, it can
+// be declared as a string when it does not have a custom message
+type Precondition struct {
+	Sh  string `yaml:"sh"`
+	Msg string `yaml:"msg,omitempty"`
+}
+
+// UnmarshalYAML reads a precondition from a string or from an object
+func (p *Precondition) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var sh string
+	err := unmarshal(&sh)
+	if err == nil {
+		p.Sh = sh
+		return nil
+	}
+
+	type plain Precondition
+	return unmarshal((*plain)(p))
+}
+
+// MarshalYAML writes the precondition as a string if it does not have a message
+func (p Precondition) MarshalYAML() (interface{}, error) {
+	if len(p.Msg) == 0 {
+		return p.Sh, nil
+	}
+
+	type plain Precondition
+	return plain(p), nil
+}
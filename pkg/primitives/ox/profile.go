@@ -0,0 +1,86 @@
+package ox
+
+import "fmt"
+
+// ProfileEnv is the env variable used to select a profile when it is not set
+// with a flag
+const ProfileEnv = "ELK_PROFILE"
+
+// Profile is the configuration of an environment, like dev or prod, that
+// overwrites the env, vars, env_file and properties of the tasks of the file
+type Profile struct {
+	Env     map[string]string `yaml:"-"`
+	Vars    map[string]string `yaml:"-"`
+	EnvFile string            `yaml:"env_file,omitempty"`
+	Tasks   map[string]Task   `yaml:"tasks,omitempty"`
+
+	// EnvSh and VarsSh are the env variables and vars whose value is the output
+	// of a shell command, by name. They are read and written with Env and Vars
+	// by UnmarshalYAML and MarshalYAML
+	EnvSh  map[string]string `yaml:"-"`
+	VarsSh map[string]string `yaml:"-"`
+}
+
+// UnmarshalYAML reads a profile where env and vars can be declared as {sh: cmd}
+func (p *Profile) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain Profile
+	var err error
+	p.EnvSh, p.VarsSh, err = unmarshalSh(unmarshal, (*plain)(p), &p.Env, &p.Vars)
+	return err
+}
+
+// MarshalYAML writes a profile with the env and vars declared as {sh: cmd}
+func (p Profile) MarshalYAML() (interface{}, error) {
+	type plain Profile
+	return marshalSh(plain(p), p.Env, p.EnvSh, p.Vars, p.VarsSh)
+}
+
+// GetProfile get a profile by its name
+func (e *Elk) GetProfile(name string) (*Profile, error) {
+	profile, ok := e.Profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrProfileNotFound, name)
+	}
+
+	return &profile, nil
+}
+
+// SetProfile applies a profile to the file, its env, vars and env_file
+// overwrite the global ones and each of its tasks extends the task with the
+// same name. An empty name does not apply any profile
+func (e *Elk) SetProfile(name string) error {
+	if len(name) == 0 {
+		return nil
+	}
+
+	profile, err := e.GetProfile(name)
+	if err != nil {
+		return err
+	}
+
+	for taskName := range profile.Tasks {
+		if !e.HasTask(taskName) {
+			return fmt.Errorf("profile '%s' overwrites '%s': %w", name, taskName, ErrTaskNotFound)
+		}
+	}
+
+	e.Env, e.EnvSh = mergeSh(e.Env, e.EnvSh, profile.Env, profile.EnvSh)
+	e.Vars, e.VarsSh = mergeSh(e.Vars, e.VarsSh, profile.Vars, profile.VarsSh)
+
+	if len(profile.EnvFile) > 0 {
+		e.EnvFile = profile.EnvFile
+	}
+
+	for taskName, overwrite := range profile.Tasks {
+		task := e.Tasks[taskName]
+
+		result := extendTask(task, overwrite)
+		if len(overwrite.Extends) == 0 {
+			result.Extends = task.Extends
+		}
+
+		e.Tasks[taskName] = result
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+package ox
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+func TestElkSetProfile(t *testing.T) {
+	content := `
+env:
+  FOO: BAR
+  HOST: localhost
+vars:
+  replicas: 1
+profiles:
+  prod:
+    env_file: ./prod.env
+    env:
+      HOST: example.com
+      VERSION:
+        sh: git describe
+    vars:
+      replicas: 3
+    tasks:
+      deploy:
+        cmds:
+          - kubectl apply -f prod.yml
+        deps:
+          - name: test
+tasks:
+  test:
+    cmds:
+      - go test
+  build:
+    cmds:
+      - go build
+  deploy:
+    extends: build
+    cmds:
+      - kubectl apply -f dev.yml
+    deps:
+      - name: build
+`
+
+	var e Elk
+	err := yaml.Unmarshal([]byte(content), &e)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = e.SetProfile("prod")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if e.Env["FOO"] != "BAR" || e.Env["HOST"] != "example.com" {
+		t.Errorf("The env should be overwritten by the profile but it was %v", e.Env)
+	}
+
+	if e.EnvSh["VERSION"] != "git describe" {
+		t.Errorf("The env 'VERSION' should be the output of '%s' but it was '%s'", "git describe", e.EnvSh["VERSION"])
+	}
+
+	if e.Vars["replicas"] != "3" {
+		t.Errorf("The var should be '%s' but it was '%s'", "3", e.Vars["replicas"])
+	}
+
+	if e.EnvFile != "./prod.env" {
+		t.Errorf("The env_file should be '%s' but it was '%s'", "./prod.env", e.EnvFile)
+	}
+
+	deploy := e.Tasks["deploy"]
+	if len(deploy.Cmds) != 1 || deploy.Cmds[0].Cmd != "kubectl apply -f prod.yml" {
+		t.Errorf("The cmds should be overwritten by the profile but they were %v", deploy.Cmds)
+	}
+
+	expected := []Dep{{Name: "build"}, {Name: "test"}}
+	if !reflect.DeepEqual(deploy.Deps, expected) {
+		t.Errorf("The deps should be %v but they were %v", expected, deploy.Deps)
+	}
+
+	if !reflect.DeepEqual(deploy.Extends, Extends{"build"}) {
+		t.Errorf("The task should keep extending '%s' but it extends %v", "build", deploy.Extends)
+	}
+}
+
+func TestElkSetProfileEmpty(t *testing.T) {
+	e := Elk{
+		Env: map[string]string{"FOO": "BAR"},
+	}
+
+	err := e.SetProfile("")
+	if err != nil {
+		t.Error(err)
+	}
+
+	if e.Env["FOO"] != "BAR" {
+		t.Errorf("The env should not change but it was %v", e.Env)
+	}
+}
+
+func TestElkSetProfileNotFound(t *testing.T) {
+	e := Elk{
+		Profiles: map[string]Profile{
+			"dev": {},
+			"prod": {
+				Tasks: map[string]Task{
+					"deploy": {},
+				},
+			},
+		},
+		Tasks: map[string]Task{
+			"build": {},
+		},
+	}
+
+	err := e.SetProfile("staging")
+	if !errors.Is(err, ErrProfileNotFound) {
+		t.Errorf("The error should be '%v' but it was '%v' instead", ErrProfileNotFound, err)
+	}
+
+	err = e.SetProfile("prod")
+	if !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("The error should be '%v' but it was '%v' instead", ErrTaskNotFound, err)
+	}
+}
@@ -0,0 +1,68 @@
+package ox
+
+import "time"
+
+// Retry is the policy used to run a command again when it fails
+type Retry struct {
+	Attempts  int           `yaml:"attempts"`
+	Delay     time.Duration `yaml:"delay,omitempty"`
+	Backoff   float64       `yaml:"backoff,omitempty"`
+	MaxDelay  time.Duration `yaml:"max_delay,omitempty"`
+	ExitCodes []int         `yaml:"exit_codes,omitempty"`
+}
+
+// GetAttempts returns the total of times that a command can run
+func (r *Retry) GetAttempts() int {
+	if r == nil || r.Attempts < 1 {
+		return 1
+	}
+
+	return r.Attempts
+}
+
+// GetDelay returns how long to wait before running a specific attempt, the
+// delay grows by the backoff factor after each attempt until reaching max delay
+func (r *Retry) GetDelay(attempt int) time.Duration {
+	if r == nil || attempt < 2 {
+		return 0
+	}
+
+	backoff := r.Backoff
+	if backoff <= 0 {
+		backoff = 1
+	}
+
+	delay := float64(r.Delay)
+	for i := 2; i < attempt; i++ {
+		delay *= backoff
+		if r.MaxDelay > 0 && delay > float64(r.MaxDelay) {
+			break
+		}
+	}
+
+	if r.MaxDelay > 0 && delay > float64(r.MaxDelay) {
+		return r.MaxDelay
+	}
+
+	return time.Duration(delay)
+}
+
+// ShouldRetry returns if a command that exit with a specific code should run
+// again, if there are no exit codes declared every failure is retried
+func (r *Retry) ShouldRetry(exitCode int) bool {
+	if r == nil {
+		return false
+	}
+
+	if len(r.ExitCodes) == 0 {
+		return true
+	}
+
+	for _, code := range r.ExitCodes {
+		if code == exitCode {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,55 @@
+package ox
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRetryGetAttempts(t *testing.T) {
+	var retry *Retry
+	if retry.GetAttempts() != 1 {
+		t.Errorf("A command without retry should run %d time but it runs %d instead", 1, retry.GetAttempts())
+	}
+
+	retry = &Retry{Attempts: 3}
+	if retry.GetAttempts() != 3 {
+		t.Errorf("The command should run %d times but it runs %d instead", 3, retry.GetAttempts())
+	}
+}
+
+func TestRetryGetDelay(t *testing.T) {
+	retry := &Retry{
+		Attempts: 5,
+		Delay:    time.Second,
+		Backoff:  2,
+		MaxDelay: 3 * time.Second,
+	}
+
+	expected := []time.Duration{0, time.Second, 2 * time.Second, 3 * time.Second, 3 * time.Second}
+	for i, delay := range expected {
+		attempt := i + 1
+		if retry.GetDelay(attempt) != delay {
+			t.Errorf("The delay for attempt %d should be '%s' but it was '%s' instead", attempt, delay, retry.GetDelay(attempt))
+		}
+	}
+}
+
+func TestRetryShouldRetry(t *testing.T) {
+	retry := &Retry{
+		Attempts:  2,
+		ExitCodes: []int{2},
+	}
+
+	if !retry.ShouldRetry(2) {
+		t.Error("It should retry because the exit code is declared")
+	}
+
+	if retry.ShouldRetry(1) {
+		t.Error("It should not retry because the exit code is not declared")
+	}
+
+	retry.ExitCodes = nil
+	if !retry.ShouldRetry(1) {
+		t.Error("It should retry any exit code when there are no exit codes declared")
+	}
+}
@@ -0,0 +1,274 @@
+package ox
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/jjzcru/elk/pkg/shell"
+)
+
+// Types of the values of an ox file
+const (
+	typeString   = "string"
+	typeInteger  = "integer"
+	typeNumber   = "number"
+	typeBoolean  = "boolean"
+	typeDuration = "duration"
+	typeObject   = "object"
+	typeArray    = "array"
+	typeMap      = "map"
+)
+
+// durationPattern matches the durations accepted by time.ParseDuration
+const durationPattern = `^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`
+
+// schema describes a value of an ox file, it is used to validate a file and to
+// generate its JSON Schema
+type schema struct {
+	Type       string
+	Properties map[string]*schema
+	Required   []string
+
+	// Items is the schema of the values of an array or a map
+	Items *schema
+
+	// Short is true when an object or an array can also be declared as a string
+	Short bool
+
+	Enum    []string
+	Minimum *int
+
+	// Name is used to declare the schema once in the JSON Schema when it is
+	// used by more than one property
+	Name string
+}
+
+func stringSchema() *schema {
+	return &schema{Type: typeString}
+}
+
+func enumSchema(values ...string) *schema {
+	return &schema{Type: typeString, Enum: values}
+}
+
+func integerSchema(minimum *int) *schema {
+	return &schema{Type: typeInteger, Minimum: minimum}
+}
+
+func booleanSchema() *schema {
+	return &schema{Type: typeBoolean}
+}
+
+func durationSchema() *schema {
+	return &schema{Type: typeDuration}
+}
+
+func arraySchema(items *schema) *schema {
+	return &schema{Type: typeArray, Items: items}
+}
+
+func mapSchema(items *schema) *schema {
+	return &schema{Type: typeMap, Items: items}
+}
+
+func objectSchema(properties map[string]*schema, required ...string) *schema {
+	return &schema{Type: typeObject, Properties: properties, Required: required}
+}
+
+// shortSchema returns a schema that can also be declared as a string
+func shortSchema(s *schema) *schema {
+	s.Short = true
+	return s
+}
+
+var zero = 0
+
+func getElkSchema() *schema {
+	shells := enumSchema(shell.Builtin, "bash", "sh", "zsh")
+
+	// The env and vars can be a value or the output of a shell command
+	values := mapSchema(shortSchema(objectSchema(map[string]*schema{
+		"sh": stringSchema(),
+	}, "sh")))
+
+	retry := objectSchema(map[string]*schema{
+		"attempts":   integerSchema(&zero),
+		"delay":      durationSchema(),
+		"backoff":    {Type: typeNumber},
+		"max_delay":  durationSchema(),
+		"exit_codes": arraySchema(integerSchema(nil)),
+	})
+
+	hooks := arraySchema(shortSchema(objectSchema(map[string]*schema{
+		"cmd":  stringSchema(),
+		"task": stringSchema(),
+	})))
+
+	task := objectSchema(map[string]*schema{
+		"title": stringSchema(),
+		"tags":  arraySchema(stringSchema()),
+		"cmds": arraySchema(shortSchema(objectSchema(map[string]*schema{
+			"cmd":     stringSchema(),
+			"retry":   retry,
+			"timeout": durationSchema(),
+			"shell":   shells,
+		}, "cmd"))),
+		"env":         values,
+		"vars":        values,
+		"env_file":    stringSchema(),
+		"description": stringSchema(),
+		"dir":         stringSchema(),
+		// An empty format is the same as not setting it, elk init writes it
+		"log": objectSchema(map[string]*schema{
+			"out":    stringSchema(),
+			"format": enumSchema(append([]string{""}, getLogFormatNames()...)...),
+			"error":  stringSchema(),
+		}),
+		"sources":   stringSchema(),
+		"generates": arraySchema(stringSchema()),
+		"deps": arraySchema(objectSchema(map[string]*schema{
+			"name":         stringSchema(),
+			"detached":     booleanSchema(),
+			"ignore_error": booleanSchema(),
+		}, "name")),
+		"ignore_error": booleanSchema(),
+		"retry":        retry,
+		"timeout":      durationSchema(),
+		"before":       hooks,
+		"after":        hooks,
+		"on_failure":   hooks,
+		"finally":      hooks,
+		"if":           stringSchema(),
+		"preconditions": arraySchema(shortSchema(objectSchema(map[string]*schema{
+			"sh":  stringSchema(),
+			"msg": stringSchema(),
+		}, "sh"))),
+		"shell":        shells,
+		"interactive":  booleanSchema(),
+		"grace_period": durationSchema(),
+		"matrix":       mapSchema(arraySchema(stringSchema())),
+		"args": arraySchema(objectSchema(map[string]*schema{
+			"name":        stringSchema(),
+			"type":        enumSchema(ArgString, ArgInt, ArgBool, ArgEnum),
+			"values":      arraySchema(stringSchema()),
+			"default":     stringSchema(),
+			"required":    booleanSchema(),
+			"description": stringSchema(),
+		}, "name")),
+		"limits": objectSchema(map[string]*schema{
+			"memory":      stringSchema(),
+			"cpu":         durationSchema(),
+			"open_files":  integerSchema(&zero),
+			"processes":   integerSchema(&zero),
+			"nice":        integerSchema(nil),
+			"io_class":    enumSchema(shell.IORealtime, shell.IOBestEffort, shell.IOIdle),
+			"io_priority": integerSchema(nil),
+		}),
+		"extends": shortSchema(arraySchema(stringSchema())),
+	})
+	task.Name = "task"
+
+	return objectSchema(map[string]*schema{
+		"version":      stringSchema(),
+		"env":          values,
+		"vars":         values,
+		"env_file":     stringSchema(),
+		"concurrency":  integerSchema(&zero),
+		"shell":        shells,
+		"grace_period": durationSchema(),
+		"include": arraySchema(shortSchema(objectSchema(map[string]*schema{
+			"file":      stringSchema(),
+			"namespace": stringSchema(),
+			"dir":       stringSchema(),
+		}, "file"))),
+		"profiles": mapSchema(objectSchema(map[string]*schema{
+			"env":      values,
+			"vars":     values,
+			"env_file": stringSchema(),
+			"tasks":    mapSchema(task),
+		})),
+		"tasks": mapSchema(task),
+	})
+}
+
+// JSONSchema returns the JSON Schema of an ox file, it is used by the editors to
+// validate and complete the file
+func JSONSchema() ([]byte, error) {
+	definitions := make(map[string]interface{})
+
+	document := getElkSchema().toJSON(definitions)
+	document["$schema"] = "http://json-schema.org/draft-07/schema#"
+	document["title"] = "ox"
+	document["definitions"] = definitions
+
+	return json.MarshalIndent(document, "", "  ")
+}
+
+// toJSON returns the JSON Schema of a value, the schemas with a name are added
+// to definitions and referenced
+func (s *schema) toJSON(definitions map[string]interface{}) map[string]interface{} {
+	if len(s.Name) > 0 {
+		if _, ok := definitions[s.Name]; !ok {
+			named := *s
+			named.Name = ""
+			definitions[s.Name] = named.toJSON(definitions)
+		}
+
+		return map[string]interface{}{"$ref": "#/definitions/" + s.Name}
+	}
+
+	document := make(map[string]interface{})
+
+	// Any scalar is read as a string
+	scalar := []string{"string", "number", "boolean"}
+
+	switch s.Type {
+	case typeString:
+		document["type"] = scalar
+	case typeDuration:
+		document["type"] = []string{"string", "integer"}
+		document["pattern"] = durationPattern
+	case typeMap:
+		document["type"] = "object"
+		document["additionalProperties"] = s.Items.toJSON(definitions)
+	case typeArray:
+		document["type"] = "array"
+		document["items"] = s.Items.toJSON(definitions)
+	case typeObject:
+		properties := make(map[string]interface{})
+		for name, property := range s.Properties {
+			properties[name] = property.toJSON(definitions)
+		}
+
+		document["type"] = "object"
+		document["properties"] = properties
+		document["additionalProperties"] = false
+
+		if len(s.Required) > 0 {
+			required := append([]string{}, s.Required...)
+			sort.Strings(required)
+			document["required"] = required
+		}
+	default:
+		document["type"] = s.Type
+	}
+
+	if len(s.Enum) > 0 {
+		document["enum"] = s.Enum
+	}
+
+	if s.Minimum != nil {
+		document["minimum"] = *s.Minimum
+	}
+
+	if s.Short {
+		return map[string]interface{}{
+			"anyOf": []interface{}{
+				map[string]interface{}{"type": scalar},
+				document,
+			},
+		}
+	}
+
+	return document
+}
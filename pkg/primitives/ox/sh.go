@@ -0,0 +1,207 @@
+package ox
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/jjzcru/elk/pkg/shell"
+	"gopkg.in/yaml.v2"
+)
+
+// shCache stores the output of the shell commands evaluated during a build by
+// the shell, the directory and the command, so a command runs only once
+type shCache map[string]string
+
+// eval returns the values of the vars or env variables declared as shell
+// commands, the commands run with the shell in dir with the env variables from env
+func (c shCache) eval(kind string, commands map[string]string, sh string, dir string, env map[string]string) (map[string]string, error) {
+	values := make(map[string]string)
+	for name, cmd := range commands {
+		key := strings.Join([]string{sh, dir, cmd}, "\x00")
+		value, ok := c[key]
+		if !ok {
+			var err error
+			value, err = runSh(cmd, sh, dir, env)
+			if err != nil {
+				return nil, fmt.Errorf("the command '%s' of %s '%s' failed: %v", cmd, kind, name, err)
+			}
+			c[key] = value
+		}
+
+		values[name] = value
+	}
+
+	return values, nil
+}
+
+// placeholderSh returns a placeholder with the command of each value instead
+// of its output, it has the signature of eval so it can be used in its place
+func placeholderSh(kind string, commands map[string]string, sh string, dir string, env map[string]string) (map[string]string, error) {
+	values := make(map[string]string)
+	for name, cmd := range commands {
+		values[name] = fmt.Sprintf("<sh: %s>", cmd)
+	}
+
+	return values, nil
+}
+
+// runSh returns the output of a shell command without the trailing new lines
+func runSh(cmd string, sh string, dir string, env map[string]string) (string, error) {
+	var err error
+	if len(dir) == 0 {
+		dir, err = os.Getwd()
+		if err != nil {
+			return "", err
+		}
+	}
+
+	var envs []string
+	for k, v := range env {
+		envs = append(envs, fmt.Sprintf("%s=%s", k, v))
+	}
+
+	var out bytes.Buffer
+	err = shell.Run(context.Background(), shell.Command{
+		Shell:  sh,
+		Cmd:    cmd,
+		Dir:    dir,
+		Env:    envs,
+		Stdout: &out,
+		Stderr: os.Stderr,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(out.String(), "\r\n"), nil
+}
+
+// shValue is a value of env or vars, it is a string or a shell command
+// declared as {sh: cmd}
+type shValue struct {
+	value string
+	cmd   string
+	isSh  bool
+}
+
+// UnmarshalYAML reads the value as it was written or the command if it is
+// declared as {sh: cmd}
+func (v *shValue) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	err := unmarshal(&v.value)
+	if err == nil {
+		return nil
+	}
+
+	var sh map[string]string
+	if unmarshal(&sh) != nil || len(sh) != 1 {
+		return err
+	}
+
+	cmd, ok := sh["sh"]
+	if !ok {
+		return err
+	}
+
+	v.cmd = cmd
+	v.isSh = true
+	return nil
+}
+
+// unmarshalSh decodes a node into out, env and vars are decoded apart because
+// their values can be declared as {sh: cmd}, the commands are returned by name
+func unmarshalSh(unmarshal func(interface{}) error, out interface{}, env *map[string]string, vars *map[string]string) (map[string]string, map[string]string, error) {
+	err := unmarshal(out)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var values struct {
+		Env  map[string]shValue `yaml:"env"`
+		Vars map[string]shValue `yaml:"vars"`
+	}
+
+	err = unmarshal(&values)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var envSh, varsSh map[string]string
+	*env, envSh = splitSh(values.Env)
+	*vars, varsSh = splitSh(values.Vars)
+
+	return envSh, varsSh, nil
+}
+
+// splitSh returns the values and the commands of env or vars by name
+func splitSh(values map[string]shValue) (map[string]string, map[string]string) {
+	commands := make(map[string]string)
+	if values == nil {
+		return nil, commands
+	}
+
+	static := make(map[string]string)
+	for name, value := range values {
+		if value.isSh {
+			commands[name] = value.cmd
+			continue
+		}
+
+		static[name] = value.value
+	}
+
+	return static, commands
+}
+
+// marshalSh encodes in adding its env and vars, with the entries that are
+// declared as shell commands written as {sh: cmd}
+func marshalSh(in interface{}, env, envSh, vars, varsSh map[string]string) (interface{}, error) {
+	data, err := yaml.Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw yaml.MapSlice
+	err = yaml.Unmarshal(data, &raw)
+	if err != nil {
+		return nil, err
+	}
+
+	raw = appendSh(raw, "env", env, envSh)
+	raw = appendSh(raw, "vars", vars, varsSh)
+
+	return raw, nil
+}
+
+func appendSh(raw yaml.MapSlice, key string, values map[string]string, commands map[string]string) yaml.MapSlice {
+	if len(values) == 0 && len(commands) == 0 {
+		return raw
+	}
+
+	var names []string
+	for name := range values {
+		names = append(names, name)
+	}
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var items yaml.MapSlice
+	for _, name := range names {
+		if cmd, ok := commands[name]; ok {
+			items = append(items, yaml.MapItem{
+				Key:   name,
+				Value: yaml.MapSlice{{Key: "sh", Value: cmd}},
+			})
+			continue
+		}
+
+		items = append(items, yaml.MapItem{Key: name, Value: values[name]})
+	}
+
+	return append(raw, yaml.MapItem{Key: key, Value: items})
+}
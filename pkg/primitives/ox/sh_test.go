@@ -0,0 +1,229 @@
+package ox
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+func TestBuildSh(t *testing.T) {
+	content := `
+env:
+  GREETING:
+    sh: echo hello
+vars:
+  name: elk
+  version:
+    sh: echo 1.0.0
+tasks:
+  hello:
+    env:
+      TARGET:
+        sh: echo $GREETING world
+    vars:
+      tag:
+        sh: echo v$TARGET
+    cmds:
+      - echo {{.name}}
+  empty:
+`
+	e := Elk{}
+	err := yaml.Unmarshal([]byte(content), &e)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if e.VarsSh["version"] != "echo 1.0.0" {
+		t.Errorf("The var should be declared as the command '%s' but it was '%s' instead", "echo 1.0.0", e.VarsSh["version"])
+	}
+
+	if e.Vars["name"] != "elk" {
+		t.Errorf("The var should be '%s' but it was '%s' instead", "elk", e.Vars["name"])
+	}
+
+	err = e.Build()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	err = e.EvalSh("hello")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	task := e.Tasks["hello"]
+	values := map[string]string{
+		"GREETING": task.Env["GREETING"],
+		"TARGET":   task.Env["TARGET"],
+		"version":  task.Vars["version"],
+		"tag":      task.Vars["tag"],
+		"name":     task.Vars["name"],
+	}
+
+	expected := map[string]string{
+		"GREETING": "hello",
+		"TARGET":   "hello world",
+		"version":  "1.0.0",
+		"tag":      "vhello world",
+		"name":     "elk",
+	}
+
+	for name, value := range expected {
+		if values[name] != value {
+			t.Errorf("The value of '%s' should be '%s' but it was '%s' instead", name, value, values[name])
+		}
+	}
+}
+
+func TestEvalSh(t *testing.T) {
+	content := `
+tasks:
+  hello:
+    deps:
+      - name: dep
+    finally:
+      - task: hook
+  dep:
+    vars:
+      dep:
+        sh: echo dep
+  hook:
+    vars:
+      hook:
+        sh: echo hook
+  other:
+    vars:
+      acct:
+        sh: exit 3
+`
+	e := Elk{}
+	err := yaml.Unmarshal([]byte(content), &e)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	err = e.Build()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	err = e.EvalSh("hello")
+	if err != nil {
+		t.Errorf("Only the commands of the task, its deps and its hooks should run but it returns '%v'", err)
+		return
+	}
+
+	if e.Tasks["dep"].Vars["dep"] != "dep" || e.Tasks["hook"].Vars["hook"] != "hook" {
+		t.Errorf("The commands of the deps and the hooks should run but the vars were %v and %v", e.Tasks["dep"].Vars, e.Tasks["hook"].Vars)
+	}
+
+	if e.Tasks["other"].VarsSh["acct"] != "exit 3" {
+		t.Error("The commands of the other tasks should not run")
+	}
+
+	err = e.EvalSh("other")
+	if err == nil {
+		t.Error("Should throw an error because the command of the task fails")
+	}
+}
+
+func TestMarshalSh(t *testing.T) {
+	task := Task{
+		Vars:   map[string]string{"name": "elk"},
+		VarsSh: map[string]string{"version": "git describe"},
+	}
+
+	data, err := yaml.Marshal(task)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	result := Task{}
+	err = yaml.Unmarshal(data, &result)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if result.Vars["name"] != "elk" || result.VarsSh["version"] != "git describe" {
+		t.Errorf("The vars should be the same after writing them but they were %v and %v", result.Vars, result.VarsSh)
+	}
+}
+
+func TestUnmarshalShValues(t *testing.T) {
+	content := `
+vars:
+  ver: 1.10
+  code: 010
+  enabled: yes
+  version:
+    sh: echo 1.0.0
+tasks:
+  hello:
+    env:
+      VERSION: 1.20
+      BUILD:
+        sh: echo 1
+`
+	e := Elk{}
+	err := yaml.Unmarshal([]byte(content), &e)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	expected := map[string]string{
+		"ver":     "1.10",
+		"code":    "010",
+		"enabled": "yes",
+	}
+
+	for name, value := range expected {
+		if e.Vars[name] != value {
+			t.Errorf("The var '%s' should be '%s' as it was written but it was '%s' instead", name, value, e.Vars[name])
+		}
+	}
+
+	task := e.Tasks["hello"]
+	if task.Env["VERSION"] != "1.20" || task.EnvSh["BUILD"] != "echo 1" {
+		t.Errorf("The env should be '%s' and the command '%s' but they were %v and %v", "1.20", "echo 1", task.Env, task.EnvSh)
+	}
+}
+
+func TestUnmarshalShTypeError(t *testing.T) {
+	contents := []string{`
+vars:
+  version:
+    sh: echo 1.0.0
+  list:
+    - 1
+`, `
+env:
+  VERSION:
+    sh: echo 1.0.0
+    msg: version
+`, `
+tasks:
+  hello:
+    env:
+      VERSION:
+        sh: echo 1.0.0
+      BUILD:
+        sh: echo 1
+    deps: build
+`}
+
+	for _, content := range contents {
+		e := Elk{}
+		err := yaml.Unmarshal([]byte(content), &e)
+		if err == nil {
+			t.Errorf("The file should have a type error:%s", content)
+		}
+	}
+}
@@ -2,23 +2,70 @@ package ox
 
 import (
 	"fmt"
+	"time"
+
 	"github.com/jjzcru/elk/pkg/file"
 )
 
 // Task is the data structure for the task to run
 type Task struct {
-	Title       string            `yaml:"title"`
-	Tags        []string          `yaml:"tags"`
-	Cmds        []string          `yaml:"cmds"`
-	Env         map[string]string `yaml:"env,omitempty"`
-	Vars        map[string]string `yaml:"vars,omitempty"`
-	EnvFile     string            `yaml:"env_file,omitempty"`
-	Description string            `yaml:"description,omitempty"`
-	Dir         string            `yaml:"dir,omitempty"`
-	Log         Log               `yaml:"log,omitempty"`
-	Sources     string            `yaml:"sources,omitempty"`
-	Deps        []Dep             `yaml:"deps,omitempty"`
-	IgnoreError bool              `yaml:"ignore_error,omitempty"`
+	Title         string              `yaml:"title"`
+	Tags          []string            `yaml:"tags"`
+	Cmds          []Cmd               `yaml:"cmds"`
+	Env           map[string]string   `yaml:"-"`
+	Vars          map[string]string   `yaml:"-"`
+	EnvFile       string              `yaml:"env_file,omitempty"`
+	Description   string              `yaml:"description,omitempty"`
+	Dir           string              `yaml:"dir,omitempty"`
+	Log           Log                 `yaml:"log,omitempty"`
+	Sources       string              `yaml:"sources,omitempty"`
+	Generates     []string            `yaml:"generates,omitempty"`
+	Deps          []Dep               `yaml:"deps,omitempty"`
+	IgnoreError   bool                `yaml:"ignore_error,omitempty"`
+	Retry         *Retry              `yaml:"retry,omitempty"`
+	Timeout       time.Duration       `yaml:"timeout,omitempty"`
+	Before        []Hook              `yaml:"before,omitempty"`
+	After         []Hook              `yaml:"after,omitempty"`
+	OnFailure     []Hook              `yaml:"on_failure,omitempty"`
+	Finally       []Hook              `yaml:"finally,omitempty"`
+	If            string              `yaml:"if,omitempty"`
+	Preconditions []Precondition      `yaml:"preconditions,omitempty"`
+	Shell         string              `yaml:"shell,omitempty"`
+	Interactive   bool                `yaml:"interactive,omitempty"`
+	GracePeriod   time.Duration       `yaml:"grace_period,omitempty"`
+	Matrix        map[string][]string `yaml:"matrix,omitempty"`
+	Args          []Arg               `yaml:"args,omitempty"`
+	Limits        *Limits             `yaml:"limits,omitempty"`
+	Extends       Extends             `yaml:"extends,omitempty"`
+
+	// EnvSh and VarsSh are the env variables and vars whose value is the output
+	// of a shell command, by name, they are evaluated by Elk.EvalSh. They are
+	// read and written with Env and Vars by UnmarshalYAML and MarshalYAML
+	EnvSh  map[string]string `yaml:"-"`
+	VarsSh map[string]string `yaml:"-"`
+
+	// globalEnvSh and globalVarsSh are the names of the global env variables
+	// and vars declared as shell commands that the task inherits
+	globalEnvSh  []string
+	globalVarsSh []string
+
+	// instances are the tasks created from the matrix of the task, they get
+	// the args of the task
+	instances []string
+}
+
+// UnmarshalYAML reads a task where env and vars can be declared as {sh: cmd}
+func (t *Task) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain Task
+	var err error
+	t.EnvSh, t.VarsSh, err = unmarshalSh(unmarshal, (*plain)(t), &t.Env, &t.Vars)
+	return err
+}
+
+// MarshalYAML writes a task with the env and vars declared as {sh: cmd}
+func (t Task) MarshalYAML() (interface{}, error) {
+	type plain Task
+	return marshalSh(plain(t), t.Env, t.EnvSh, t.Vars, t.VarsSh)
 }
 
 type Dep struct {
@@ -69,3 +116,13 @@ func (t *Task) GetEnvs() []string {
 
 	return envs
 }
+
+// GetHooks return all the hooks declared in the task
+func (t *Task) GetHooks() []Hook {
+	var hooks []Hook
+	hooks = append(hooks, t.Before...)
+	hooks = append(hooks, t.After...)
+	hooks = append(hooks, t.OnFailure...)
+	hooks = append(hooks, t.Finally...)
+	return hooks
+}
@@ -0,0 +1,445 @@
+package ox
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ValidationError is an error found in an ox file with its position in the file
+type ValidationError struct {
+	Line   int
+	Column int
+	Err    error
+}
+
+func (v ValidationError) Error() string {
+	return fmt.Sprintf("%d:%d: %v", v.Line, v.Column, v.Err)
+}
+
+// Unwrap returns the error found in the file
+func (v ValidationError) Unwrap() error {
+	return v.Err
+}
+
+// validator collects the errors of an ox file
+type validator struct {
+	dir    string
+	errors []ValidationError
+
+	// invalid are the nodes with an error, they are removed from the file to
+	// check its tasks
+	invalid map[*yaml.Node]bool
+}
+
+// Validate checks an ox file without running any command and returns the
+// errors found sorted by their position, the error is returned when the file
+// can't be read or is not valid YAML
+func Validate(filePath string) ([]ValidationError, error) {
+	path, err := filepath.Abs(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var document yaml.Node
+	err = yaml.Unmarshal(data, &document)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(document.Content) == 0 {
+		return nil, nil
+	}
+
+	v := validator{
+		dir:     filepath.Dir(path),
+		invalid: make(map[*yaml.Node]bool),
+	}
+
+	root := document.Content[0]
+	v.check(root, getElkSchema(), "")
+	v.checkTasks(path, &document)
+
+	sort.SliceStable(v.errors, func(i, j int) bool {
+		if v.errors[i].Line == v.errors[j].Line {
+			return v.errors[i].Column < v.errors[j].Column
+		}
+		return v.errors[i].Line < v.errors[j].Line
+	})
+
+	return v.errors, nil
+}
+
+func (v *validator) add(node *yaml.Node, err error) {
+	v.invalid[node] = true
+	v.errors = append(v.errors, ValidationError{
+		Line:   node.Line,
+		Column: node.Column,
+		Err:    err,
+	})
+}
+
+// check validates a node against its schema, name is the path of the node
+func (v *validator) check(node *yaml.Node, s *schema, name string) {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!null" {
+		return
+	}
+
+	if s.Short && node.Kind == yaml.ScalarNode {
+		return
+	}
+
+	switch s.Type {
+	case typeObject:
+		if node.Kind != yaml.MappingNode {
+			v.add(node, typeError(name, s))
+			return
+		}
+
+		properties := make(map[string]bool)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+
+			// The keys merged from an anchor are not checked
+			if key.Value == "<<" {
+				continue
+			}
+
+			property, ok := s.Properties[key.Value]
+			if !ok {
+				v.add(key, fmt.Errorf("%w '%s'", ErrUnknownProperty, getName(name, key.Value)))
+				continue
+			}
+
+			properties[key.Value] = true
+			v.check(value, property, getName(name, key.Value))
+		}
+
+		for _, property := range s.Required {
+			if !properties[property] {
+				v.add(node, fmt.Errorf("%w '%s'", ErrMissingProperty, getName(name, property)))
+			}
+		}
+	case typeMap:
+		if node.Kind != yaml.MappingNode {
+			v.add(node, typeError(name, s))
+			return
+		}
+
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if key.Value == "<<" {
+				continue
+			}
+
+			v.check(value, s.Items, getName(name, key.Value))
+		}
+	case typeArray:
+		if node.Kind != yaml.SequenceNode {
+			v.add(node, typeError(name, s))
+			return
+		}
+
+		for i, item := range node.Content {
+			v.check(item, s.Items, fmt.Sprintf("%s[%d]", name, i))
+		}
+	default:
+		if node.Kind != yaml.ScalarNode || !isScalarType(node, s.Type) {
+			v.add(node, typeError(name, s))
+			return
+		}
+
+		if len(s.Enum) > 0 && !contains(s.Enum, node.Value) {
+			// An empty value is not listed, it means that the value is not set
+			var values []string
+			for _, value := range s.Enum {
+				if len(value) > 0 {
+					values = append(values, value)
+				}
+			}
+
+			v.add(node, fmt.Errorf("%w: '%s' should be one of %s", ErrInvalidValue, name, strings.Join(values, ", ")))
+		}
+
+		if s.Minimum != nil {
+			value, err := strconv.Atoi(node.Value)
+			if err == nil && value < *s.Minimum {
+				v.add(node, fmt.Errorf("%w: '%s' can't be lower than %d", ErrInvalidValue, name, *s.Minimum))
+			}
+		}
+	}
+}
+
+// checkTasks validates the paths, the regex and the references to other tasks
+// of the tasks declared in the file, the nodes with errors are ignored
+func (v *validator) checkTasks(path string, document *yaml.Node) {
+	root := document.Content[0]
+	if v.invalid[root] {
+		return
+	}
+
+	e, err := v.load(path, document)
+	if err != nil {
+		node := root
+		if key, _ := getProperty(root, "include"); key != nil {
+			node = key
+		}
+
+		v.add(node, err)
+		return
+	}
+
+	if _, envFile := getProperty(root, "env_file"); envFile != nil {
+		v.checkEnvFile(envFile)
+	}
+
+	if _, profiles := getProperty(root, "profiles"); profiles != nil {
+		for i := 0; i+1 < len(profiles.Content); i += 2 {
+			profile := profiles.Content[i+1]
+			if _, envFile := getProperty(profile, "env_file"); envFile != nil {
+				v.checkEnvFile(envFile)
+			}
+
+			_, tasks := getProperty(profile, "tasks")
+			if tasks == nil {
+				continue
+			}
+
+			for j := 0; j+1 < len(tasks.Content); j += 2 {
+				v.checkTask(e, tasks.Content[j])
+			}
+		}
+	}
+
+	_, tasks := getProperty(root, "tasks")
+	if tasks == nil {
+		return
+	}
+
+	cycles := make(map[string]bool)
+	for i := 0; i+1 < len(tasks.Content); i += 2 {
+		key, task := tasks.Content[i], tasks.Content[i+1]
+		if task.Kind != yaml.MappingNode {
+			continue
+		}
+
+		if _, envFile := getProperty(task, "env_file"); envFile != nil {
+			v.checkEnvFile(envFile)
+		}
+
+		if _, sources := getProperty(task, "sources"); sources != nil {
+			if _, err := regexp.Compile(sources.Value); err != nil {
+				v.add(sources, fmt.Errorf("%w: %v", ErrInvalidSources, err))
+			}
+		}
+
+		if _, deps := getProperty(task, "deps"); deps != nil {
+			for _, dep := range deps.Content {
+				if _, name := getProperty(dep, "name"); name != nil {
+					v.checkTask(e, name)
+				}
+			}
+		}
+
+		for _, lifecycle := range []string{"before", "after", "on_failure", "finally"} {
+			_, hooks := getProperty(task, lifecycle)
+			if hooks == nil {
+				continue
+			}
+
+			for _, hook := range hooks.Content {
+				if _, name := getProperty(hook, "task"); name != nil {
+					v.checkTask(e, name)
+				}
+			}
+		}
+
+		if _, extends := getProperty(task, "extends"); extends != nil {
+			if extends.Kind == yaml.ScalarNode {
+				v.checkTask(e, extends)
+			}
+
+			for _, name := range extends.Content {
+				v.checkTask(e, name)
+			}
+		}
+
+		if cycles[key.Value] {
+			continue
+		}
+
+		// The deps that do not exist are already reported
+		cycle, _ := e.getCycle(key.Value, nil)
+		if len(cycle) == 0 || cycle[0] != key.Value {
+			continue
+		}
+
+		for _, name := range cycle {
+			cycles[name] = true
+		}
+
+		v.add(key, fmt.Errorf("%w: %s", ErrCircularDependency, strings.Join(cycle, " -> ")))
+	}
+}
+
+// load returns the elk object of the file without the nodes with errors
+func (v *validator) load(path string, document *yaml.Node) (*Elk, error) {
+	if len(v.invalid) == 0 {
+		return FromFile(path)
+	}
+
+	data, err := yaml.Marshal(v.removeInvalid(document))
+	if err != nil {
+		return nil, err
+	}
+
+	return fromData(data, path, nil)
+}
+
+// removeInvalid returns a copy of a node without the nodes with errors, an
+// entry of a map is removed when its key or its value has an error
+func (v *validator) removeInvalid(node *yaml.Node) *yaml.Node {
+	isInvalid := func(n *yaml.Node) bool {
+		return v.invalid[n] || (n.Kind == yaml.AliasNode && v.invalid[n.Alias])
+	}
+
+	result := *node
+	result.Content = nil
+
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if isInvalid(key) || isInvalid(value) {
+				continue
+			}
+
+			result.Content = append(result.Content, v.removeInvalid(key), v.removeInvalid(value))
+		}
+
+		return &result
+	}
+
+	for _, child := range node.Content {
+		if isInvalid(child) {
+			continue
+		}
+
+		result.Content = append(result.Content, v.removeInvalid(child))
+	}
+
+	return &result
+}
+
+// checkTask validates that a task exists
+func (v *validator) checkTask(e *Elk, node *yaml.Node) {
+	if !e.HasTask(node.Value) {
+		v.add(node, fmt.Errorf("%w: '%s'", ErrTaskNotFound, node.Value))
+	}
+}
+
+// checkEnvFile validates that an env file exists, relative to the file
+func (v *validator) checkEnvFile(node *yaml.Node) {
+	if len(node.Value) == 0 {
+		return
+	}
+
+	info, err := os.Stat(getPath(v.dir, node.Value))
+	if err != nil || info.IsDir() {
+		v.add(node, fmt.Errorf("%w: %s", ErrEnvFileNotFound, node.Value))
+	}
+}
+
+// getProperty returns the key and the value of a property of an object
+func getProperty(node *yaml.Node, name string) (*yaml.Node, *yaml.Node) {
+	if node.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == name {
+			return node.Content[i], node.Content[i+1]
+		}
+	}
+
+	return nil, nil
+}
+
+func getName(parent string, name string) string {
+	if len(parent) == 0 {
+		return name
+	}
+
+	return parent + "." + name
+}
+
+// isScalarType returns if a scalar can be read as a type
+func isScalarType(node *yaml.Node, t string) bool {
+	switch t {
+	case typeInteger:
+		return node.Tag == "!!int"
+	case typeNumber:
+		return node.Tag == "!!int" || node.Tag == "!!float"
+	case typeBoolean:
+		return node.Tag == "!!bool"
+	case typeDuration:
+		if node.Tag == "!!int" {
+			return true
+		}
+
+		_, err := time.ParseDuration(node.Value)
+		return err == nil
+	default:
+		return true
+	}
+}
+
+func typeError(name string, s *schema) error {
+	descriptions := map[string]string{
+		typeString:   "a string",
+		typeInteger:  "an integer",
+		typeNumber:   "a number",
+		typeBoolean:  "a boolean",
+		typeDuration: "a duration",
+		typeObject:   "an object",
+		typeArray:    "a list",
+		typeMap:      "a map",
+	}
+
+	description := descriptions[s.Type]
+	if s.Short {
+		description = "a string or " + description
+	}
+
+	if len(name) == 0 {
+		return fmt.Errorf("%w: the file should be %s", ErrInvalidType, description)
+	}
+
+	return fmt.Errorf("%w: '%s' should be %s", ErrInvalidType, name, description)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
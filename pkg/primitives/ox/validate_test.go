@@ -0,0 +1,277 @@
+package ox
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"ox.yml": `
+env_file: ./.env
+tasks:
+  build:
+    env_file: ./missing.env
+    sources: "*.go"
+    log:
+      out: ./build.log
+    deps:
+      - name: test
+      - name: lint
+    cmds:
+      - go build
+  test:
+    deps:
+      - name: vet
+    cmds:
+      - go test
+  vet:
+    deps:
+      - name: test
+    cmds:
+      - go vet
+`,
+		".env": "FOO=BAR",
+	})
+	defer os.RemoveAll(dir)
+
+	validationErrors, err := Validate(filepath.Join(dir, "ox.yml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		line   int
+		column int
+		err    error
+	}{
+		{5, 15, ErrEnvFileNotFound},
+		{6, 14, ErrInvalidSources},
+		{11, 15, ErrTaskNotFound},
+		{14, 3, ErrCircularDependency},
+	}
+
+	if len(validationErrors) != len(tests) {
+		t.Fatalf("There should be %d errors but there were %d: %v", len(tests), len(validationErrors), validationErrors)
+	}
+
+	for i, tt := range tests {
+		validationError := validationErrors[i]
+		if validationError.Line != tt.line || validationError.Column != tt.column {
+			t.Errorf("The error '%v' should be at %d:%d", validationError, tt.line, tt.column)
+		}
+
+		if !errors.Is(validationError, tt.err) {
+			t.Errorf("The error should be '%v' but it was '%v' instead", tt.err, validationError.Err)
+		}
+	}
+
+	expected := "14:3: circular dependency: test -> vet -> test"
+	if validationErrors[3].Error() != expected {
+		t.Errorf("The error should be '%s' but it was '%s' instead", expected, validationErrors[3].Error())
+	}
+}
+
+func TestValidateSchema(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"ox.yml": `
+concurrency: -1
+tasks:
+  build:
+    dependencies:
+      - test
+    ignore_error: maybe
+    timeout: 5 minutes
+    log:
+      format: today
+    cmds: go build
+    deps:
+      - detached: true
+  test:
+    extends: [build]
+    cmds:
+      - cmd: go test
+        shell: fish
+`,
+	})
+	defer os.RemoveAll(dir)
+
+	validationErrors, err := Validate(filepath.Join(dir, "ox.yml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		line   int
+		column int
+		err    error
+	}{
+		{2, 14, ErrInvalidValue},
+		{5, 5, ErrUnknownProperty},
+		{7, 19, ErrInvalidType},
+		{8, 14, ErrInvalidType},
+		{10, 15, ErrInvalidValue},
+		{11, 11, ErrInvalidType},
+		{13, 9, ErrMissingProperty},
+		{18, 16, ErrInvalidValue},
+	}
+
+	if len(validationErrors) != len(tests) {
+		t.Fatalf("There should be %d errors but there were %d: %v", len(tests), len(validationErrors), validationErrors)
+	}
+
+	for i, tt := range tests {
+		validationError := validationErrors[i]
+		if validationError.Line != tt.line || validationError.Column != tt.column {
+			t.Errorf("The error '%v' should be at %d:%d", validationError, tt.line, tt.column)
+		}
+
+		if !errors.Is(validationError, tt.err) {
+			t.Errorf("The error should be '%v' but it was '%v' instead", tt.err, validationError.Err)
+		}
+	}
+
+	expected := "5:5: unknown property 'tasks.build.dependencies'"
+	if validationErrors[1].Error() != expected {
+		t.Errorf("The error should be '%s' but it was '%s' instead", expected, validationErrors[1].Error())
+	}
+}
+
+func TestValidateSchemaAndTasks(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"ox.yml": `
+tasks:
+  build:
+    dependencies:
+      - test
+    timeout: 5 minutes
+    env_file: ./missing.env
+    sources: "*.go"
+    deps:
+      - name: lint
+      - name: test
+    cmds:
+      - go build
+  test:
+    deps:
+      - name: build
+      - name: vet
+    cmds:
+      - go test
+`,
+	})
+	defer os.RemoveAll(dir)
+
+	validationErrors, err := Validate(filepath.Join(dir, "ox.yml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		line   int
+		column int
+		err    error
+	}{
+		{3, 3, ErrCircularDependency},
+		{4, 5, ErrUnknownProperty},
+		{6, 14, ErrInvalidType},
+		{7, 15, ErrEnvFileNotFound},
+		{8, 14, ErrInvalidSources},
+		{10, 15, ErrTaskNotFound},
+		{17, 15, ErrTaskNotFound},
+	}
+
+	if len(validationErrors) != len(tests) {
+		t.Fatalf("There should be %d errors but there were %d: %v", len(tests), len(validationErrors), validationErrors)
+	}
+
+	for i, tt := range tests {
+		validationError := validationErrors[i]
+		if validationError.Line != tt.line || validationError.Column != tt.column {
+			t.Errorf("The error '%v' should be at %d:%d", validationError, tt.line, tt.column)
+		}
+
+		if !errors.Is(validationError, tt.err) {
+			t.Errorf("The error should be '%v' but it was '%v' instead", tt.err, validationError.Err)
+		}
+	}
+
+	expected := "3:3: circular dependency: build -> test -> build"
+	if validationErrors[0].Error() != expected {
+		t.Errorf("The error should be '%s' but it was '%s' instead", expected, validationErrors[0].Error())
+	}
+}
+
+func TestValidateValid(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"ox.yml": `
+version: 1
+env:
+  VERSION:
+    sh: git describe
+include:
+  - file: ./docker.yml
+    namespace: docker
+tasks:
+  build:
+    extends: base
+    sources: \.go$
+    timeout: 1m
+    log:
+      out: ./build.log
+      format: RFC3339
+    deps:
+      - name: docker:build
+    before:
+      - echo start
+      - task: base
+    cmds:
+      - go build
+      - cmd: go vet
+        retry:
+          attempts: 3
+  base:
+    matrix:
+      go: [1.13, 1.14]
+    limits:
+      cpu: 10
+      memory: 1G
+`,
+		"docker.yml": `
+tasks:
+  build:
+    cmds:
+      - docker build .
+`,
+	})
+	defer os.RemoveAll(dir)
+
+	validationErrors, err := Validate(filepath.Join(dir, "ox.yml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(validationErrors) > 0 {
+		t.Errorf("The file should be valid but it has the errors %v", validationErrors)
+	}
+}
+
+func TestJSONSchema(t *testing.T) {
+	schema, err := JSONSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The published schema has to be updated when the syntax changes
+	published, err := ioutil.ReadFile("../../../docs/schema/ox.schema.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(published) != string(schema)+"\n" {
+		t.Error("The published schema is outdated, run elk validate --schema > docs/schema/ox.schema.json")
+	}
+}
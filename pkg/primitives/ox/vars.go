@@ -1,12 +1,28 @@
 package ox
 
 import (
-	"html/template"
+	"fmt"
+	"regexp"
+	"text/template"
+	"text/template/parse"
 )
 
+// outputPattern matches the outputs of the dependencies used in a command
+var outputPattern = regexp.MustCompile(`\.deps\.(\w+)\.outputs\.(\w+)`)
+
 type Vars struct {
 	Map map[string]string
 	Cmd string
+
+	// Env are the env variables of the task, used by the env function
+	Env map[string]string
+
+	// Dir is the directory of the task, the relative paths of the file
+	// functions are relative to it
+	Dir string
+
+	// Deps are the outputs of the dependencies of the task, by the name of the dependency
+	Deps map[string]map[string]string
 }
 
 func (v *Vars) Write(data []byte) (n int, err error) {
@@ -14,13 +30,18 @@ func (v *Vars) Write(data []byte) (n int, err error) {
 	return len(data), nil
 }
 
+// Process returns the command after applying the vars, it fails if the
+// command uses a var that is not declared
 func (v *Vars) Process(cmd string) (string, error) {
-	t, err := template.New("ox").Parse(cmd)
+	t, err := template.New("ox").
+		Option("missingkey=error").
+		Funcs(v.funcs()).
+		Parse(cmd)
 	if err != nil {
 		return "", err
 	}
 
-	err = t.Execute(v, v.Map)
+	err = t.Execute(v, v.data())
 	if err != nil {
 		return "", err
 	}
@@ -28,10 +49,154 @@ func (v *Vars) Process(cmd string) (string, error) {
 	return v.Cmd, nil
 }
 
+// data returns the vars and the outputs of the dependencies, which are
+// available as {{.deps.<name>.outputs.<key>}}
+func (v *Vars) data() map[string]interface{} {
+	data := make(map[string]interface{})
+	for k, value := range v.Map {
+		data[k] = value
+	}
+
+	if len(v.Deps) > 0 {
+		deps := make(map[string]interface{})
+		for name, outputs := range v.Deps {
+			deps[name] = map[string]interface{}{
+				"outputs": outputs,
+			}
+		}
+		data["deps"] = deps
+	}
+
+	return data
+}
+
 func GetCmdFromVars(vars map[string]string, cmd string) (string, error) {
+	return GetCmdFromOutputs(vars, nil, cmd)
+}
+
+// GetCmdFromOutputs returns the command after applying the vars and the outputs
+// of the dependencies of a task
+func GetCmdFromOutputs(vars map[string]string, deps map[string]map[string]string, cmd string) (string, error) {
 	v := Vars{
-		Map: vars,
+		Map:  vars,
+		Deps: deps,
 	}
 
 	return v.Process(cmd)
 }
+
+// GetCmdFromTask returns the command after applying the vars, the env and the
+// dir of a task and the outputs of its dependencies
+func GetCmdFromTask(task *Task, deps map[string]map[string]string, cmd string) (string, error) {
+	v := Vars{
+		Map:  task.Vars,
+		Env:  task.Env,
+		Dir:  task.Dir,
+		Deps: deps,
+	}
+
+	return v.Process(cmd)
+}
+
+// GetCmdWithPlaceholders returns the command of a task before its dependencies
+// run, their outputs are displayed as <deps.<name>.outputs.<key>>
+func GetCmdWithPlaceholders(task *Task, cmd string) (string, error) {
+	deps := make(map[string]map[string]string)
+	for _, dep := range task.Deps {
+		deps[dep.Name] = make(map[string]string)
+	}
+
+	for _, match := range outputPattern.FindAllStringSubmatch(cmd, -1) {
+		if outputs, ok := deps[match[1]]; ok {
+			outputs[match[2]] = fmt.Sprintf("<deps.%s.outputs.%s>", match[1], match[2])
+		}
+	}
+
+	return GetCmdFromTask(task, deps, cmd)
+}
+
+// CheckVars returns an error if a template of the task uses a var that is not
+// declared, it is checked before any task runs. The outputs of the
+// dependencies are not checked because they are known once the dependencies run
+func (t *Task) CheckVars() error {
+	templates := []string{t.If}
+	for _, cmd := range t.Cmds {
+		templates = append(templates, cmd.Cmd)
+	}
+
+	for _, hook := range t.GetHooks() {
+		templates = append(templates, hook.Cmd)
+	}
+
+	for _, precondition := range t.Preconditions {
+		templates = append(templates, precondition.Sh)
+	}
+
+	for _, cmd := range templates {
+		tree, err := template.New("ox").Funcs((&Vars{}).funcs()).Parse(cmd)
+		if err != nil {
+			return err
+		}
+
+		err = checkVars(tree.Tree.Root, t.Vars)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkVars walks a template and returns an error with the first var that is
+// not declared. The body of range and with is not checked because the dot is
+// not the vars inside of them
+func checkVars(node parse.Node, vars map[string]string) error {
+	var nodes []parse.Node
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n != nil {
+			nodes = n.Nodes
+		}
+	case *parse.ActionNode:
+		nodes = []parse.Node{n.Pipe}
+	case *parse.IfNode:
+		nodes = []parse.Node{n.Pipe, n.List, n.ElseList}
+	case *parse.RangeNode:
+		nodes = []parse.Node{n.Pipe}
+	case *parse.WithNode:
+		nodes = []parse.Node{n.Pipe}
+	case *parse.TemplateNode:
+		nodes = []parse.Node{n.Pipe}
+	case *parse.PipeNode:
+		if n != nil {
+			for _, cmd := range n.Cmds {
+				nodes = append(nodes, cmd.Args...)
+			}
+		}
+	case *parse.ChainNode:
+		nodes = []parse.Node{n.Node}
+	case *parse.FieldNode:
+		return checkVar(n.Ident[0], vars)
+	case *parse.VariableNode:
+		if len(n.Ident) > 1 && n.Ident[0] == "$" {
+			return checkVar(n.Ident[1], vars)
+		}
+	}
+
+	for _, node := range nodes {
+		err := checkVars(node, vars)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func checkVar(name string, vars map[string]string) error {
+	if _, ok := vars[name]; ok || name == "deps" {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s", ErrUndeclaredVar, name)
+}
@@ -61,3 +61,115 @@ func TestVarsProcessErrorParsing(t *testing.T) {
 		t.Error(errors.New("it should throw an error of invalid syntax"))
 	}
 }
+
+func TestGetCmdFromOutputs(t *testing.T) {
+	vars := map[string]string{
+		"registry": "docker.io",
+	}
+
+	deps := map[string]map[string]string{
+		"build": {
+			"image_tag": "v1.0.0",
+		},
+	}
+
+	inputCmd := "docker push {{.registry}}/elk:{{.deps.build.outputs.image_tag}}"
+	expectedCmd := "docker push docker.io/elk:v1.0.0"
+
+	cmd, err := GetCmdFromOutputs(vars, deps, inputCmd)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if cmd != expectedCmd {
+		t.Error(fmt.Errorf("the command should be '%s' but it was '%s' instead", expectedCmd, cmd))
+	}
+}
+
+func TestVarsProcessWithoutEscaping(t *testing.T) {
+	vars := Vars{
+		Map: map[string]string{
+			"query": `a=1&b=<2> "it's"`,
+		},
+	}
+
+	inputCmd := "echo {{.query}}"
+	expectedCmd := `echo a=1&b=<2> "it's"`
+
+	cmd, err := vars.Process(inputCmd)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if cmd != expectedCmd {
+		t.Error(fmt.Errorf("the command should be '%s' but it was '%s' instead", expectedCmd, cmd))
+	}
+}
+
+func TestVarsProcessMissingVar(t *testing.T) {
+	vars := Vars{
+		Map: map[string]string{
+			"foo": "bar",
+		},
+	}
+
+	_, err := vars.Process("echo {{.fooo}}")
+	if err == nil {
+		t.Error(errors.New("it should throw an error because the var is not declared"))
+	}
+}
+
+func TestGetCmdWithPlaceholders(t *testing.T) {
+	task := &Task{
+		Vars: map[string]string{
+			"registry": "docker.io",
+		},
+		Deps: []Dep{{Name: "build"}},
+	}
+
+	inputCmd := "docker push {{.registry}}/elk:{{.deps.build.outputs.image_tag}}"
+	expectedCmd := "docker push docker.io/elk:<deps.build.outputs.image_tag>"
+
+	cmd, err := GetCmdWithPlaceholders(task, inputCmd)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if cmd != expectedCmd {
+		t.Error(fmt.Errorf("the command should be '%s' but it was '%s' instead", expectedCmd, cmd))
+	}
+}
+
+func TestTaskCheckVars(t *testing.T) {
+	task := &Task{
+		Vars: map[string]string{
+			"registry": "docker.io",
+		},
+		Deps: []Dep{{Name: "build"}},
+		Cmds: []Cmd{
+			{Cmd: "docker push {{.registry}}/elk:{{.deps.build.outputs.tag}}"},
+			{Cmd: `{{range split "a,b" ","}}echo {{.}}{{end}}`},
+		},
+	}
+
+	err := task.CheckVars()
+	if err != nil {
+		t.Errorf("The vars of the task are declared but it returns '%v'", err)
+	}
+
+	templates := []*Task{
+		{Cmds: []Cmd{{Cmd: "echo {{.tag}}"}}},
+		{Cmds: []Cmd{{Cmd: "echo {{if .tag}}{{.tag}}{{end}}"}}},
+		{Cmds: []Cmd{{Cmd: "echo {{default \"latest\" $.tag}}"}}},
+		{Finally: []Hook{{Cmd: "echo {{upper .tag}}"}}},
+		{If: "test -n {{.tag}}"},
+		{Preconditions: []Precondition{{Sh: "test -n {{.tag}}"}}},
+	}
+
+	for _, task := range templates {
+		err := task.CheckVars()
+		if !errors.Is(err, ErrUndeclaredVar) {
+			t.Errorf("Should throw an error because the var '%s' is not declared but it returns '%v'", "tag", err)
+		}
+	}
+}
@@ -193,3 +193,14 @@ func getDetachedTaskIDs() []string {
 
 	return detachedTaskIDs
 }
+
+// isSkipped returns true if all the tasks from a detached task were skipped
+func isSkipped(outputs []*model.Output) bool {
+	for _, output := range outputs {
+		if output.Result == nil || output.Result.Status != model.TaskStatusSkipped {
+			return false
+		}
+	}
+
+	return len(outputs) > 0
+}
@@ -2,7 +2,9 @@ package graph
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
 	"sync"
 
 	"github.com/jjzcru/elk/pkg/engine"
@@ -10,20 +12,70 @@ import (
 	"github.com/jjzcru/elk/pkg/server/graph/model"
 )
 
-// TaskWG run a working group of tasks
-func TaskWG(ctx context.Context, cliEngine *engine.Engine, task string, wg *sync.WaitGroup, errChan chan map[string]error) {
+// TaskWG run a working group of tasks and returns the result of each task
+func TaskWG(ctx context.Context, cliEngine *engine.Engine, tasks []string, wg *sync.WaitGroup, errChan chan map[string]error) []*engine.Result {
 	if wg != nil {
 		defer wg.Done()
 	}
 
-	err := cliEngine.Run(ctx, task)
+	results, err := cliEngine.Run(ctx, tasks...)
+	if err == nil {
+		return results
+	}
+
+	errs, ok := err.(engine.Errors)
+	if !ok {
+		errs = engine.Errors{err}
+	}
+
+	taskErrors := make(map[string]error)
+	for _, err := range errs {
+		var taskError *engine.TaskError
+		if errors.As(err, &taskError) {
+			taskErrors[taskError.Task] = taskError.Err
+			continue
+		}
+
+		for _, task := range tasks {
+			taskErrors[task] = err
+		}
+	}
+
+	errChan <- taskErrors
+	return results
+}
+
+// DryRun returns the plan of each task in the order that they would run
+func DryRun(elk *ox.Elk, tasks []string) ([]*model.Output, error) {
+	clientEngine := &engine.Engine{
+		Elk: elk,
+	}
+
+	plan, err := clientEngine.DryRun(tasks...)
 	if err != nil {
-		errChan <- map[string]error{task: err}
+		return nil, err
+	}
+
+	var outputs []*model.Output
+	for _, task := range plan {
+		outputs = append(outputs, &model.Output{
+			Task:  task.Task,
+			Out:   []string{},
+			Error: []string{},
+			Plan:  mapPlan(task),
+		})
 	}
+
+	return outputs, nil
 }
 
-func loadTaskProperties(elk *ox.Elk, properties *model.TaskProperties) {
+func loadTaskProperties(elk *ox.Elk, tasks []string, properties *model.TaskProperties) error {
+	args := make(map[string]string)
 	if properties != nil {
+		for k, v := range properties.Args {
+			args[k] = fmt.Sprintf("%v", v)
+		}
+
 		for name, task := range elk.Tasks {
 			for k, v := range properties.Vars {
 				switch v.(type) {
@@ -52,4 +104,17 @@ func loadTaskProperties(elk *ox.Elk, properties *model.TaskProperties) {
 			elk.Tasks[name] = task
 		}
 	}
+
+	return elk.SetArgs(tasks, args, nil)
+}
+
+// setProfile applies the profile of the properties to the file, by default it
+// uses the profile set in ELK_PROFILE
+func setProfile(elk *ox.Elk, properties *model.TaskProperties) error {
+	profile := os.Getenv(ox.ProfileEnv)
+	if properties != nil && properties.Profile != nil {
+		profile = *properties.Profile
+	}
+
+	return elk.SetProfile(profile)
 }
@@ -3,6 +3,7 @@ package graph
 import (
 	"fmt"
 
+	"github.com/jjzcru/elk/pkg/engine"
 	"github.com/jjzcru/elk/pkg/primitives/ox"
 	"github.com/jjzcru/elk/pkg/server/graph/model"
 )
@@ -59,10 +60,11 @@ func mapTask(task ox.Task, name string) (*model.Task, error) {
 		Sources:     &task.Sources,
 		Deps:        []*model.Dep{},
 		IgnoreError: task.IgnoreError,
+		Args:        []*model.Arg{},
 	}
 
 	for i := range task.Cmds {
-		cmd := task.Cmds[i]
+		cmd := task.Cmds[i].Cmd
 		taskModel.Cmds = append(taskModel.Cmds, &cmd)
 	}
 
@@ -78,6 +80,10 @@ func mapTask(task ox.Task, name string) (*model.Task, error) {
 		taskModel.Deps = append(taskModel.Deps, mapDep(dep))
 	}
 
+	for _, arg := range task.Args {
+		taskModel.Args = append(taskModel.Args, mapArg(arg))
+	}
+
 	return &taskModel, nil
 }
 
@@ -102,6 +108,108 @@ func mapDep(dep ox.Dep) *model.Dep {
 	return &depModel
 }
 
+func mapArg(arg ox.Arg) *model.Arg {
+	argModel := model.Arg{
+		Name:        arg.Name,
+		Type:        model.TaskArgType(arg.GetType()),
+		Values:      []string{},
+		Required:    arg.Required,
+		Description: arg.Description,
+	}
+
+	argModel.Values = append(argModel.Values, arg.Values...)
+
+	if len(arg.Default) > 0 {
+		value := arg.Default
+		argModel.Default = &value
+	}
+
+	return &argModel
+}
+
+func mapArgInputs(args []*model.TaskArg) []ox.Arg {
+	var argList []ox.Arg
+	for _, arg := range args {
+		a := ox.Arg{
+			Name:   arg.Name,
+			Values: arg.Values,
+		}
+
+		if arg.Type != nil {
+			a.Type = arg.Type.String()
+		}
+
+		if arg.Default != nil {
+			a.Default = *arg.Default
+		}
+
+		if arg.Required != nil {
+			a.Required = *arg.Required
+		}
+
+		if arg.Description != nil {
+			a.Description = *arg.Description
+		}
+
+		argList = append(argList, a)
+	}
+
+	return argList
+}
+
+func mapOutputs(values map[string]string) map[string]interface{} {
+	outputs := make(map[string]interface{})
+	for k, v := range values {
+		outputs[k] = v
+	}
+
+	return outputs
+}
+
+func mapResult(result *engine.Result) *model.TaskResult {
+	resultModel := model.TaskResult{
+		Task:     result.Task,
+		Status:   model.TaskStatus(result.Status),
+		ExitCode: result.ExitCode,
+		StartAt:  result.StartAt,
+		EndAt:    result.EndAt,
+		Duration: result.Duration(),
+		Attempts: result.Attempts,
+		Outputs:  mapOutputs(result.Outputs),
+		Error:    mapError(result.Err),
+		Cmds:     []*model.CmdResult{},
+		Deps:     []*model.TaskResult{},
+	}
+
+	for _, cmd := range result.Cmds {
+		resultModel.Cmds = append(resultModel.Cmds, &model.CmdResult{
+			Cmd:      cmd.Cmd,
+			Status:   model.TaskStatus(cmd.Status),
+			ExitCode: cmd.ExitCode,
+			StartAt:  cmd.StartAt,
+			EndAt:    cmd.EndAt,
+			Duration: cmd.Duration(),
+			Attempts: cmd.Attempts,
+			Error:    mapError(cmd.Err),
+		})
+	}
+
+	for _, dep := range result.Deps {
+		resultModel.Deps = append(resultModel.Deps, mapResult(dep))
+	}
+
+	return &resultModel
+}
+
+func mapError(err error) *string {
+	if err == nil {
+		return nil
+	}
+
+	message := err.Error()
+	return &message
+}
+
 func mapTaskInput(task model.TaskInput) ox.Task {
 	env := make(map[string]string)
 	vars := make(map[string]string)
@@ -180,7 +288,7 @@ func mapTaskInput(task model.TaskInput) ox.Task {
 	return ox.Task{
 		Title:       title,
 		Tags:        task.Tags,
-		Cmds:        task.Cmds,
+		Cmds:        ox.NewCmds(task.Cmds...),
 		Env:         env,
 		Vars:        vars,
 		EnvFile:     envFile,
@@ -190,6 +298,7 @@ func mapTaskInput(task model.TaskInput) ox.Task {
 		IgnoreError: ignoreError,
 		Log:         log,
 		Deps:        deps,
+		Args:        mapArgInputs(task.Args),
 	}
 }
 
@@ -203,7 +312,7 @@ func mergeTaskInput(taskInput model.TaskInput, task ox.Task) ox.Task {
 	}
 
 	if taskInput.Cmds != nil {
-		task.Cmds = taskInput.Cmds
+		task.Cmds = ox.NewCmds(taskInput.Cmds...)
 	}
 
 	if taskInput.Env != nil {
@@ -274,5 +383,24 @@ func mergeTaskInput(taskInput model.TaskInput, task ox.Task) ox.Task {
 		task.IgnoreError = *taskInput.IgnoreError
 	}
 
+	if taskInput.Args != nil {
+		task.Args = mapArgInputs(taskInput.Args)
+	}
+
 	return task
 }
+
+func mapPlan(task *engine.PlannedTask) *model.TaskPlan {
+	env := make(map[string]interface{})
+	for k, v := range task.Env {
+		env[k] = v
+	}
+
+	return &model.TaskPlan{
+		Detached: task.Detached,
+		Dir:      task.Dir,
+		Deps:     task.Deps,
+		Env:      env,
+		Cmds:     task.Cmds,
+	}
+}
@@ -9,6 +9,26 @@ import (
 	"time"
 )
 
+type Arg struct {
+	Name        string      `json:"name"`
+	Type        TaskArgType `json:"type"`
+	Values      []string    `json:"values"`
+	Default     *string     `json:"default"`
+	Required    bool        `json:"required"`
+	Description string      `json:"description"`
+}
+
+type CmdResult struct {
+	Cmd      string        `json:"cmd"`
+	Status   TaskStatus    `json:"status"`
+	ExitCode int           `json:"exitCode"`
+	StartAt  time.Time     `json:"startAt"`
+	EndAt    time.Time     `json:"endAt"`
+	Duration time.Duration `json:"duration"`
+	Attempts int           `json:"attempts"`
+	Error    *string       `json:"error"`
+}
+
 type Dep struct {
 	Name     string `json:"name"`
 	Detached bool   `json:"detached"`
@@ -44,16 +64,21 @@ type Log struct {
 }
 
 type Output struct {
-	Task  string   `json:"task"`
-	Out   []string `json:"out"`
-	Error []string `json:"error"`
+	Task     string                 `json:"task"`
+	Out      []string               `json:"out"`
+	Error    []string               `json:"error"`
+	Attempts int                    `json:"attempts"`
+	Outputs  map[string]interface{} `json:"outputs"`
+	Result   *TaskResult            `json:"result"`
+	Plan     *TaskPlan              `json:"plan"`
 }
 
 type RunConfig struct {
-	Start    *time.Time     `json:"start"`
-	Deadline *time.Time     `json:"deadline"`
-	Timeout  *time.Duration `json:"timeout"`
-	Delay    *time.Duration `json:"delay"`
+	Start       *time.Time     `json:"start"`
+	Deadline    *time.Time     `json:"deadline"`
+	Timeout     *time.Duration `json:"timeout"`
+	Delay       *time.Duration `json:"delay"`
+	Concurrency *int           `json:"concurrency"`
 }
 
 type Task struct {
@@ -70,6 +95,16 @@ type Task struct {
 	Sources     *string                `json:"sources"`
 	Deps        []*Dep                 `json:"deps"`
 	IgnoreError bool                   `json:"ignoreError"`
+	Args        []*Arg                 `json:"args"`
+}
+
+type TaskArg struct {
+	Name        string       `json:"name"`
+	Type        *TaskArgType `json:"type"`
+	Values      []string     `json:"values"`
+	Default     *string      `json:"default"`
+	Required    *bool        `json:"required"`
+	Description *string      `json:"description"`
 }
 
 type TaskDep struct {
@@ -92,6 +127,7 @@ type TaskInput struct {
 	Sources     *string                `json:"sources"`
 	Deps        []*TaskDep             `json:"deps"`
 	IgnoreError *bool                  `json:"ignoreError"`
+	Args        []*TaskArg             `json:"args"`
 }
 
 type TaskLog struct {
@@ -100,11 +136,35 @@ type TaskLog struct {
 	Format *TaskLogFormat `json:"format"`
 }
 
+type TaskPlan struct {
+	Detached bool                   `json:"detached"`
+	Dir      string                 `json:"dir"`
+	Deps     []string               `json:"deps"`
+	Env      map[string]interface{} `json:"env"`
+	Cmds     []string               `json:"cmds"`
+}
+
 type TaskProperties struct {
 	Vars        map[string]interface{} `json:"vars"`
 	Env         map[string]interface{} `json:"env"`
 	EnvFile     *string                `json:"envFile"`
 	IgnoreError *bool                  `json:"ignoreError"`
+	Args        map[string]interface{} `json:"args"`
+	Profile     *string                `json:"profile"`
+}
+
+type TaskResult struct {
+	Task     string                 `json:"task"`
+	Status   TaskStatus             `json:"status"`
+	ExitCode int                    `json:"exitCode"`
+	StartAt  time.Time              `json:"startAt"`
+	EndAt    time.Time              `json:"endAt"`
+	Duration time.Duration          `json:"duration"`
+	Attempts int                    `json:"attempts"`
+	Outputs  map[string]interface{} `json:"outputs"`
+	Error    *string                `json:"error"`
+	Cmds     []*CmdResult           `json:"cmds"`
+	Deps     []*TaskResult          `json:"deps"`
 }
 
 type DetachedLogType string
@@ -154,6 +214,7 @@ const (
 	DetachedTaskStatusWaiting DetachedTaskStatus = "waiting"
 	DetachedTaskStatusRunning DetachedTaskStatus = "running"
 	DetachedTaskStatusSuccess DetachedTaskStatus = "success"
+	DetachedTaskStatusSkipped DetachedTaskStatus = "skipped"
 	DetachedTaskStatusError   DetachedTaskStatus = "error"
 )
 
@@ -161,12 +222,13 @@ var AllDetachedTaskStatus = []DetachedTaskStatus{
 	DetachedTaskStatusWaiting,
 	DetachedTaskStatusRunning,
 	DetachedTaskStatusSuccess,
+	DetachedTaskStatusSkipped,
 	DetachedTaskStatusError,
 }
 
 func (e DetachedTaskStatus) IsValid() bool {
 	switch e {
-	case DetachedTaskStatusWaiting, DetachedTaskStatusRunning, DetachedTaskStatusSuccess, DetachedTaskStatusError:
+	case DetachedTaskStatusWaiting, DetachedTaskStatusRunning, DetachedTaskStatusSuccess, DetachedTaskStatusSkipped, DetachedTaskStatusError:
 		return true
 	}
 	return false
@@ -193,6 +255,51 @@ func (e DetachedTaskStatus) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+type TaskArgType string
+
+const (
+	TaskArgTypeString TaskArgType = "string"
+	TaskArgTypeInt    TaskArgType = "int"
+	TaskArgTypeBool   TaskArgType = "bool"
+	TaskArgTypeEnum   TaskArgType = "enum"
+)
+
+var AllTaskArgType = []TaskArgType{
+	TaskArgTypeString,
+	TaskArgTypeInt,
+	TaskArgTypeBool,
+	TaskArgTypeEnum,
+}
+
+func (e TaskArgType) IsValid() bool {
+	switch e {
+	case TaskArgTypeString, TaskArgTypeInt, TaskArgTypeBool, TaskArgTypeEnum:
+		return true
+	}
+	return false
+}
+
+func (e TaskArgType) String() string {
+	return string(e)
+}
+
+func (e *TaskArgType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = TaskArgType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid TaskArgType", str)
+	}
+	return nil
+}
+
+func (e TaskArgType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type TaskLogFormat string
 
 const (
@@ -251,3 +358,50 @@ func (e *TaskLogFormat) UnmarshalGQL(v interface{}) error {
 func (e TaskLogFormat) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
+
+type TaskStatus string
+
+const (
+	TaskStatusSuccess   TaskStatus = "success"
+	TaskStatusFailed    TaskStatus = "failed"
+	TaskStatusSkipped   TaskStatus = "skipped"
+	TaskStatusCancelled TaskStatus = "cancelled"
+	TaskStatusTimeout   TaskStatus = "timeout"
+)
+
+var AllTaskStatus = []TaskStatus{
+	TaskStatusSuccess,
+	TaskStatusFailed,
+	TaskStatusSkipped,
+	TaskStatusCancelled,
+	TaskStatusTimeout,
+}
+
+func (e TaskStatus) IsValid() bool {
+	switch e {
+	case TaskStatusSuccess, TaskStatusFailed, TaskStatusSkipped, TaskStatusCancelled, TaskStatusTimeout:
+		return true
+	}
+	return false
+}
+
+func (e TaskStatus) String() string {
+	return string(e)
+}
+
+func (e *TaskStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = TaskStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid TaskStatus", str)
+	}
+	return nil
+}
+
+func (e TaskStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
@@ -6,7 +6,6 @@ package graph
 import (
 	"context"
 	"errors"
-	"sync"
 	"time"
 
 	"github.com/jjzcru/elk/pkg/engine"
@@ -15,7 +14,7 @@ import (
 	"github.com/jjzcru/elk/pkg/utils"
 )
 
-func (r *mutationResolver) Run(ctx context.Context, tasks []string, properties *model.TaskProperties) ([]*model.Output, error) {
+func (r *mutationResolver) Run(ctx context.Context, tasks []string, properties *model.TaskProperties, dryRun *bool) ([]*model.Output, error) {
 	err := auth(ctx)
 	if err != nil {
 		return nil, err
@@ -27,6 +26,11 @@ func (r *mutationResolver) Run(ctx context.Context, tasks []string, properties *
 		return nil, err
 	}
 
+	err = setProfile(elk, properties)
+	if err != nil {
+		return nil, err
+	}
+
 	if properties != nil && properties.EnvFile != nil {
 		if len(*properties.EnvFile) > 0 {
 			elk.EnvFile = *properties.EnvFile
@@ -38,12 +42,29 @@ func (r *mutationResolver) Run(ctx context.Context, tasks []string, properties *
 		return nil, err
 	}
 
+	if dryRun != nil && *dryRun {
+		elk.PlaceholderSh(tasks...)
+
+		err = loadTaskProperties(elk, tasks, properties)
+		if err != nil {
+			return nil, err
+		}
+
+		return DryRun(elk, tasks)
+	}
+
+	err = elk.EvalSh(tasks...)
+	if err != nil {
+		return nil, err
+	}
+
 	outputs := make(map[string]model.Output)
 	for _, task := range tasks {
 		outputs[task] = model.Output{
-			Task:  task,
-			Out:   []string{},
-			Error: []string{},
+			Task:     task,
+			Out:      []string{},
+			Error:    []string{},
+			Attempts: 1,
 		}
 	}
 
@@ -52,7 +73,10 @@ func (r *mutationResolver) Run(ctx context.Context, tasks []string, properties *
 		return nil, err
 	}
 
-	loadTaskProperties(elk, properties)
+	err = loadTaskProperties(elk, tasks, properties)
+	if err != nil {
+		return nil, err
+	}
 
 	errChan := make(chan map[string]error)
 
@@ -61,6 +85,12 @@ func (r *mutationResolver) Run(ctx context.Context, tasks []string, properties *
 		Executer: engine.DefaultExecuter{
 			Logger: logger,
 		},
+		NonInteractive: true,
+	}
+
+	err = clientEngine.CheckInteractive(tasks...)
+	if err != nil {
+		return nil, err
 	}
 
 	closeChannels := func() {
@@ -69,15 +99,10 @@ func (r *mutationResolver) Run(ctx context.Context, tasks []string, properties *
 		close(errChan)
 	}
 
+	var results []*engine.Result
 	go func() {
 		defer closeChannels()
-		var wg sync.WaitGroup
-		for _, task := range tasks {
-			wg.Add(1)
-			go TaskWG(ctx, clientEngine, task, &wg, errChan)
-		}
-
-		wg.Wait()
+		results = TaskWG(ctx, clientEngine, tasks, nil, errChan)
 	}()
 
 	for {
@@ -121,6 +146,16 @@ func (r *mutationResolver) Run(ctx context.Context, tasks []string, properties *
 		}
 	}
 
+	for _, result := range results {
+		output := outputs[result.Task]
+		if result.Attempts > 0 {
+			output.Attempts = result.Attempts
+		}
+		output.Outputs = mapOutputs(result.Outputs)
+		output.Result = mapResult(result)
+		outputs[result.Task] = output
+	}
+
 	var response []*model.Output
 
 	for task := range outputs {
@@ -148,13 +183,26 @@ func (r *mutationResolver) Detached(ctx context.Context, tasks []string, propert
 	var start *time.Time
 	var delay *time.Duration
 
-	loadTaskProperties(elk, properties)
+	err = setProfile(elk, properties)
+	if err != nil {
+		return nil, err
+	}
 
 	err = elk.Build()
 	if err != nil {
 		return nil, err
 	}
 
+	err = elk.EvalSh(tasks...)
+	if err != nil {
+		return nil, err
+	}
+
+	err = loadTaskProperties(elk, tasks, properties)
+	if err != nil {
+		return nil, err
+	}
+
 	isInFuture := func(start *time.Time) bool {
 		now := time.Now()
 		return start.After(now)
@@ -163,6 +211,10 @@ func (r *mutationResolver) Detached(ctx context.Context, tasks []string, propert
 	if config != nil {
 		delay = config.Delay
 
+		if config.Concurrency != nil {
+			elk.Concurrency = *config.Concurrency
+		}
+
 		if isInFuture(config.Start) {
 			start = config.Start
 		}
@@ -172,9 +224,10 @@ func (r *mutationResolver) Detached(ctx context.Context, tasks []string, propert
 	var outputs []*model.Output
 	for _, task := range tasks {
 		output := model.Output{
-			Task:  task,
-			Out:   []string{},
-			Error: []string{},
+			Task:     task,
+			Out:      []string{},
+			Error:    []string{},
+			Attempts: 1,
 		}
 
 		outputMap[task] = &output
@@ -192,7 +245,23 @@ func (r *mutationResolver) Detached(ctx context.Context, tasks []string, propert
 		Elk: elk,
 		Executer: engine.DefaultExecuter{
 			Logger: logger,
+			OnRetry: func(task string, attempt int) {
+				if output, ok := outputMap[task]; ok {
+					output.Attempts = attempt
+				}
+			},
+			OnOutput: func(task string, values map[string]string) {
+				if output, ok := outputMap[task]; ok {
+					output.Outputs = mapOutputs(values)
+				}
+			},
 		},
+		NonInteractive: true,
+	}
+
+	err = clientEngine.CheckInteractive(tasks...)
+	if err != nil {
+		return nil, err
 	}
 
 	closeChannels := func() {
@@ -212,19 +281,18 @@ func (r *mutationResolver) Detached(ctx context.Context, tasks []string, propert
 		DetachedCtxMap[id] = &contextMap
 
 		defer closeChannels()
-		var wg sync.WaitGroup
 		delayStart(delay, start)
 
 		resp := getResponseFromDetached(id)
 		resp.Status = "running"
 		updateDetachedTask(id, resp)
 
-		for _, task := range tasks {
-			wg.Add(1)
-			go TaskWG(ctx, clientEngine, task, &wg, errChan)
+		results := TaskWG(ctx, clientEngine, tasks, nil, errChan)
+		for _, result := range results {
+			if output, ok := outputMap[result.Task]; ok {
+				output.Result = mapResult(result)
+			}
 		}
-
-		wg.Wait()
 	}(id)
 
 	detachedTasks, err := func() ([]*model.Task, error) {
@@ -323,6 +391,9 @@ func (r *mutationResolver) Detached(ctx context.Context, tasks []string, propert
 				resp := getResponseFromDetached(id)
 				if resp.Status == "running" {
 					resp.Status = "success"
+					if isSkipped(outputs) {
+						resp.Status = "skipped"
+					}
 				}
 				endAt := time.Now()
 				resp.EndAt = &endAt
@@ -0,0 +1,36 @@
+package shell
+
+import "time"
+
+// Classes of the IO scheduling of a process
+const (
+	IORealtime   = "realtime"
+	IOBestEffort = "best-effort"
+	IOIdle       = "idle"
+)
+
+// Limits are the resources that the programs of a command can use, they are
+// only applied on Linux. A value of 0 does not change the limit
+type Limits struct {
+	// Memory is the maximum size in bytes of the virtual memory of a process
+	Memory uint64
+
+	// CPU is the maximum CPU time of a process, it receives SIGXCPU once it
+	// reaches it and SIGKILL a second later
+	CPU time.Duration
+
+	// OpenFiles is the maximum number of files that a process can open
+	OpenFiles uint64
+
+	// Processes is the maximum number of processes of the user, the programs
+	// can not start new processes after it is reached
+	Processes uint64
+
+	// Nice is the scheduling priority, from -20 to 19 where 19 is the lowest
+	Nice int
+
+	// IOClass and IOPriority are the IO scheduling of a process, the priority
+	// goes from 0 to 7 where 7 is the lowest
+	IOClass    string
+	IOPriority int
+}
@@ -0,0 +1,135 @@
+package shell
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"runtime"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	ioprioWhoProcess = 1
+	ioprioClassShift = 13
+)
+
+var ioClasses = map[string]int{
+	IORealtime:   1,
+	IOBestEffort: 2,
+	IOIdle:       3,
+}
+
+// limitsHelper is the name elk uses to start itself to set the limits of a
+// program before it runs, the program replaces elk in the same process
+const limitsHelper = "elk-limits"
+
+func init() {
+	if len(os.Args) < 3 || os.Args[0] != limitsHelper {
+		return
+	}
+
+	err := execWithLimits(os.Args[1], os.Args[2], os.Args[3:])
+	_, _ = fmt.Fprintf(os.Stderr, "elk: %v\n", err)
+	if os.IsNotExist(err) {
+		os.Exit(127)
+	}
+	os.Exit(126)
+}
+
+// wrap changes a program so it starts with elk, which sets the limits and
+// then runs the program. This way the limits are set before the program runs
+// any instruction and the processes it starts inherit them
+func (l *Limits) wrap(cmd *exec.Cmd) error {
+	if l == nil || *l == (Limits{}) {
+		return nil
+	}
+
+	limits, err := json.Marshal(l)
+	if err != nil {
+		return err
+	}
+
+	cmd.Args = append([]string{limitsHelper, string(limits), cmd.Path}, cmd.Args...)
+	cmd.Path = "/proc/self/exe"
+	return nil
+}
+
+// execWithLimits sets the limits to the current process and replaces it with
+// a program
+func execWithLimits(limits string, path string, args []string) error {
+	var l Limits
+	err := json.Unmarshal([]byte(limits), &l)
+	if err != nil {
+		return err
+	}
+
+	// The priorities belong to a thread, the program has to run in the same
+	// thread that set them
+	runtime.LockOSThread()
+
+	err = l.apply()
+	if err != nil {
+		return err
+	}
+
+	err = syscall.Exec(path, args, os.Environ())
+	if err != nil {
+		return &os.PathError{Op: "exec", Path: path, Err: err}
+	}
+
+	return nil
+}
+
+// apply sets the limits to the current process
+func (l *Limits) apply() error {
+	rlimits := []struct {
+		resource int
+		value    uint64
+		name     string
+	}{
+		{unix.RLIMIT_AS, l.Memory, "memory"},
+		{unix.RLIMIT_CPU, uint64(math.Ceil(l.CPU.Seconds())), "cpu"},
+		{unix.RLIMIT_NOFILE, l.OpenFiles, "open_files"},
+		{unix.RLIMIT_NPROC, l.Processes, "processes"},
+	}
+
+	for _, rlimit := range rlimits {
+		if rlimit.value == 0 {
+			continue
+		}
+
+		limit := syscall.Rlimit{Cur: rlimit.value, Max: rlimit.value}
+
+		// A process receives SIGXCPU when it reaches the CPU limit and it has
+		// one second to stop before it is killed
+		if rlimit.resource == unix.RLIMIT_CPU {
+			limit.Max++
+		}
+
+		err := syscall.Setrlimit(rlimit.resource, &limit)
+		if err != nil {
+			return fmt.Errorf("limit %s: %v", rlimit.name, err)
+		}
+	}
+
+	if l.Nice != 0 {
+		err := unix.Setpriority(unix.PRIO_PROCESS, 0, l.Nice)
+		if err != nil {
+			return fmt.Errorf("limit nice: %v", err)
+		}
+	}
+
+	if class, ok := ioClasses[l.IOClass]; ok {
+		prio := class<<ioprioClassShift | l.IOPriority
+		_, _, errno := unix.Syscall(unix.SYS_IOPRIO_SET, ioprioWhoProcess, 0, uintptr(prio))
+		if errno != 0 {
+			return fmt.Errorf("limit io: %v", errno)
+		}
+	}
+
+	return nil
+}
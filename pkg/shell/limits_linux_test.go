@@ -0,0 +1,76 @@
+package shell
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"mvdan.cc/sh/interp"
+)
+
+func TestRunLimits(t *testing.T) {
+	for _, name := range getShells(t) {
+		var out bytes.Buffer
+		err := Run(context.Background(), Command{
+			Shell:  name,
+			Cmd:    `sh -c 'ulimit -n; cut -d" " -f19 /proc/self/stat'`,
+			Env:    []string{"PATH=" + os.Getenv("PATH")},
+			Stdout: &out,
+			Limits: &Limits{
+				OpenFiles: 64,
+				Nice:      5,
+			},
+		})
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		expected := "64\n5\n"
+		if out.String() != expected {
+			t.Errorf("The output of the shell '%s' should be %q but it was %q instead", name, expected, out.String())
+		}
+	}
+}
+
+func TestRunLimitsCPU(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	err := Run(ctx, Command{
+		Shell:  "sh",
+		Cmd:    "while :; do :; done",
+		Env:    []string{"PATH=" + os.Getenv("PATH")},
+		Limits: &Limits{CPU: 500 * time.Millisecond},
+	})
+
+	// The shell is stopped by SIGXCPU, that is 24 in Linux
+	if err != interp.ExitStatus(128+24) {
+		t.Errorf("The command should exit with %d but it was '%v' instead", 128+24, err)
+	}
+
+	if ctx.Err() != nil {
+		t.Error("The command should stop when it reaches the cpu limit")
+	}
+}
+
+func TestRunLimitsMemory(t *testing.T) {
+	var out bytes.Buffer
+	err := Run(context.Background(), Command{
+		Cmd:    "sh -c 'ulimit -v'",
+		Env:    []string{"PATH=" + os.Getenv("PATH")},
+		Stdout: &out,
+		Limits: &Limits{Memory: 512 << 20},
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if strings.TrimSpace(out.String()) != "524288" {
+		t.Errorf("The memory limit should be %s but it was %s instead", "524288", strings.TrimSpace(out.String()))
+	}
+}
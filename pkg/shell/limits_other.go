@@ -0,0 +1,10 @@
+// +build !linux
+
+package shell
+
+import "os/exec"
+
+// wrap does not set the limits outside of Linux
+func (l *Limits) wrap(cmd *exec.Cmd) error {
+	return nil
+}
@@ -0,0 +1,179 @@
+package shell
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"sync"
+	"syscall"
+	"time"
+
+	"mvdan.cc/sh/expand"
+	"mvdan.cc/sh/interp"
+)
+
+// DefaultGracePeriod is the time a command has to stop after it receives
+// SIGTERM before it is killed, when the command does not set one
+const DefaultGracePeriod = 2 * time.Second
+
+// pollInterval is how often a process group that was terminated is checked
+const pollInterval = 50 * time.Millisecond
+
+// start runs a program with its limits and waits until it finish, without a
+// terminal the program runs in its own process group. When the context is
+// cancelled the whole group receives SIGTERM and, if any process of the group
+// is still running after the grace period, SIGKILL. A program in the group of
+// elk receives the signals alone
+func start(ctx context.Context, cmd *exec.Cmd, gracePeriod time.Duration, limits *Limits) error {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = processGroup()
+	}
+	group := leadsGroup(cmd.SysProcAttr)
+
+	if gracePeriod <= 0 {
+		gracePeriod = DefaultGracePeriod
+	}
+
+	err := limits.wrap(cmd)
+	if err != nil {
+		return err
+	}
+
+	err = cmd.Start()
+	if err != nil {
+		return err
+	}
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+
+		select {
+		case <-done:
+			return
+		case <-ctx.Done():
+		}
+
+		_ = terminate(cmd.Process, group)
+
+		deadline := time.NewTimer(gracePeriod)
+		defer deadline.Stop()
+
+		ticker := time.NewTicker(pollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-deadline.C:
+				_ = kill(cmd.Process, group)
+				return
+			case <-ticker.C:
+				if !isRunning(cmd.Process, group) {
+					return
+				}
+			}
+		}
+	}()
+
+	err = cmd.Wait()
+	close(done)
+
+	// The processes started by the program could still be running
+	<-stopped
+
+	return err
+}
+
+// exitStatus converts the error of a program into the exit status of the shell
+func exitStatus(ctx context.Context, err error) (error, bool) {
+	exitErr, ok := err.(*exec.ExitError)
+	if !ok {
+		return err, false
+	}
+
+	if status, ok := exitErr.Sys().(syscall.WaitStatus); ok {
+		if status.Signaled() && ctx.Err() != nil {
+			return ctx.Err(), true
+		}
+
+		// Like the shells, a program killed by a signal, for example when it
+		// reaches a limit, exits with 128 plus the number of the signal
+		if status.Signaled() {
+			return interp.ExitStatus(128 + int(status.Signal())), true
+		}
+		return interp.ExitStatus(status.ExitStatus()), true
+	}
+
+	return interp.ExitStatus(1), true
+}
+
+// execModule runs the programs called by the builtin interpreter, it works
+// like interp.DefaultExec but the programs are stopped with their process group.
+// When ctty is set the programs that use it lead a new session with it as
+// their controlling terminal, like with the shells installed in the system
+func execModule(gracePeriod time.Duration, limits *Limits, ctty *os.File) interp.ModuleExec {
+	// A terminal is the controlling terminal of one session at a time, the
+	// programs that run while another one has it only use it for their input
+	// and output
+	var mu sync.Mutex
+	var session bool
+
+	return func(ctx context.Context, path string, args []string) error {
+		mc, _ := interp.FromModuleContext(ctx)
+		if path == "" {
+			_, _ = fmt.Fprintf(mc.Stderr, "%q: executable file not found in $PATH\n", args[0])
+			return interp.ExitStatus(127)
+		}
+
+		cmd := exec.Cmd{
+			Path:   path,
+			Args:   args,
+			Env:    execEnv(mc.Env),
+			Dir:    mc.Dir,
+			Stdin:  mc.Stdin,
+			Stdout: mc.Stdout,
+			Stderr: mc.Stderr,
+		}
+
+		mu.Lock()
+		if !session {
+			cmd.SysProcAttr = terminalSession(&cmd, ctty)
+		}
+		if cmd.SysProcAttr != nil {
+			session = true
+			defer func() {
+				mu.Lock()
+				session = false
+				mu.Unlock()
+			}()
+		}
+		mu.Unlock()
+
+		err := start(ctx, &cmd, gracePeriod, limits)
+		if err, ok := exitStatus(ctx, err); ok {
+			return err
+		}
+
+		if _, ok := err.(*exec.Error); ok {
+			_, _ = fmt.Fprintf(mc.Stderr, "%v\n", err)
+			return interp.ExitStatus(127)
+		}
+
+		return err
+	}
+}
+
+// execEnv returns the variables that are exported by the interpreter
+func execEnv(env expand.Environ) []string {
+	var list []string
+	env.Each(func(name string, vr expand.Variable) bool {
+		if vr.Exported {
+			list = append(list, name+"="+vr.String())
+		}
+		return true
+	})
+	return list
+}
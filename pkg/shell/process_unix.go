@@ -0,0 +1,65 @@
+// +build !windows
+
+package shell
+
+import (
+	"os"
+	"syscall"
+)
+
+// processGroup starts a program as the leader of a new process group. When
+// elk has a terminal the program stays in the group of elk instead, like in a
+// shell, so it can read from the terminal without being stopped
+func processGroup() *syscall.SysProcAttr {
+	if hasTerminal() {
+		return nil
+	}
+
+	return &syscall.SysProcAttr{Setpgid: true}
+}
+
+// hasTerminal returns if elk has a controlling terminal
+func hasTerminal() bool {
+	tty, err := os.Open("/dev/tty")
+	if err != nil {
+		return false
+	}
+
+	_ = tty.Close()
+	return true
+}
+
+// leadsGroup returns if a program starts as the leader of its own process group
+func leadsGroup(attr *syscall.SysProcAttr) bool {
+	return attr != nil && (attr.Setpgid || attr.Setsid)
+}
+
+// terminate sends SIGTERM to the process group of a program, or only to the
+// program when it does not lead its group
+func terminate(p *os.Process, group bool) error {
+	if !group {
+		return p.Signal(syscall.SIGTERM)
+	}
+
+	return syscall.Kill(-p.Pid, syscall.SIGTERM)
+}
+
+// kill sends SIGKILL to the process group of a program, or only to the
+// program when it does not lead its group
+func kill(p *os.Process, group bool) error {
+	if !group {
+		return p.Kill()
+	}
+
+	return syscall.Kill(-p.Pid, syscall.SIGKILL)
+}
+
+// isRunning returns if a process of the group of a program is still running,
+// or the program when it does not lead its group
+func isRunning(p *os.Process, group bool) bool {
+	if !group {
+		return p.Signal(syscall.Signal(0)) == nil
+	}
+
+	return syscall.Kill(-p.Pid, 0) == nil
+}
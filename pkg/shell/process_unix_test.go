@@ -0,0 +1,154 @@
+// +build !windows
+
+package shell
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/creack/pty"
+)
+
+func TestRunCancelProcessGroup(t *testing.T) {
+	for _, name := range getShells(t) {
+		dir, err := ioutil.TempDir("", "elk")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+
+		err = Run(ctx, Command{
+			Shell: name,
+			Cmd:   "sh -c 'sleep 30 & echo $! > pid; wait'",
+			Dir:   dir,
+			Env:   []string{"PATH=" + os.Getenv("PATH")},
+
+			GracePeriod: 500 * time.Millisecond,
+		})
+		cancel()
+
+		if err == nil {
+			t.Errorf("The shell '%s' should return an error when is cancelled", name)
+		}
+
+		content, err := ioutil.ReadFile(filepath.Join(dir, "pid"))
+		_ = os.RemoveAll(dir)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		pid, err := strconv.Atoi(strings.TrimSpace(string(content)))
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if isAlive(pid) {
+			_ = syscall.Kill(pid, syscall.SIGKILL)
+			t.Errorf("The shell '%s' should stop the processes started by the command", name)
+		}
+	}
+}
+
+// isAlive returns if a process is running, a zombie process is not running
+func isAlive(pid int) bool {
+	stat, err := ioutil.ReadFile(filepath.Join("/proc", strconv.Itoa(pid), "stat"))
+	if err != nil {
+		return syscall.Kill(pid, 0) == nil
+	}
+
+	fields := strings.Fields(string(stat[bytes.LastIndexByte(stat, ')')+1:]))
+	return len(fields) > 0 && fields[0] != "Z"
+}
+
+func TestRunGracePeriod(t *testing.T) {
+	for _, name := range getShells(t) {
+		ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+
+		var out bytes.Buffer
+		_ = Run(ctx, Command{
+			Shell:       name,
+			Cmd:         "sh -c 'trap \"echo stopping; sleep 0.2; echo stopped; exit 0\" TERM; while true; do sleep 0.05; done'",
+			Env:         []string{"PATH=" + os.Getenv("PATH")},
+			Stdout:      &out,
+			Stderr:      ioutil.Discard,
+			GracePeriod: 5 * time.Second,
+		})
+		cancel()
+
+		if !strings.Contains(out.String(), "stopped") {
+			t.Errorf("The shell '%s' should let the command stop during the grace period but the output was '%s'", name, out.String())
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), 200*time.Millisecond)
+
+		start := time.Now()
+		_ = Run(ctx, Command{
+			Shell:       name,
+			Cmd:         "sh -c 'trap \"\" TERM; while true; do sleep 0.05; done'",
+			Env:         []string{"PATH=" + os.Getenv("PATH")},
+			GracePeriod: 300 * time.Millisecond,
+		})
+		cancel()
+
+		if time.Since(start) > 2*time.Second {
+			t.Errorf("The shell '%s' should kill the command after the grace period", name)
+		}
+	}
+}
+
+func TestRunReadTerminal(t *testing.T) {
+	ptmx, tty, err := pty.Open()
+	if err != nil {
+		t.Skip(err)
+	}
+	defer ptmx.Close()
+	defer tty.Close()
+
+	// The commands that are not interactive read from the terminal of elk
+	stdin := os.Stdin
+	os.Stdin = tty
+	defer func() { os.Stdin = stdin }()
+
+	go func() { _, _ = io.Copy(ioutil.Discard, ptmx) }()
+
+	for _, name := range getShells(t) {
+		_, err = ptmx.Write([]byte("abc\n"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+
+		var out bytes.Buffer
+		err = Run(ctx, Command{
+			Shell:  name,
+			Cmd:    "sh -c 'read x; echo $x'",
+			Env:    []string{"PATH=" + os.Getenv("PATH")},
+			Stdin:  os.Stdin,
+			Stdout: &out,
+		})
+		cancel()
+
+		if err != nil {
+			t.Errorf("The shell '%s' returns an unexpected error: %v", name, err)
+			continue
+		}
+
+		if strings.TrimSpace(out.String()) != "abc" {
+			t.Errorf("The shell '%s' should read '%s' from the terminal but it was '%s' instead", name, "abc", out.String())
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package shell
+
+import (
+	"os"
+	"syscall"
+)
+
+// processGroup returns nil because programs can not be stopped by group on windows
+func processGroup() *syscall.SysProcAttr {
+	return nil
+}
+
+// leadsGroup returns false because there are no process groups on windows
+func leadsGroup(attr *syscall.SysProcAttr) bool {
+	return false
+}
+
+// terminate kills a program, windows do not support SIGTERM
+func terminate(p *os.Process, group bool) error {
+	return p.Kill()
+}
+
+// kill kills a program
+func kill(p *os.Process, group bool) error {
+	return p.Kill()
+}
+
+// isRunning returns false because the program is killed right away on windows
+func isRunning(p *os.Process, group bool) bool {
+	return false
+}
@@ -0,0 +1,129 @@
+package shell
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+	"time"
+
+	"mvdan.cc/sh/expand"
+	"mvdan.cc/sh/interp"
+	"mvdan.cc/sh/syntax"
+)
+
+// Builtin is the POSIX interpreter built into elk, it is used when a shell is not set
+const Builtin = "builtin"
+
+var shells = map[string]bool{
+	Builtin: true,
+	"bash":  true,
+	"sh":    true,
+	"zsh":   true,
+}
+
+// IsValid returns if a shell is supported, an empty shell is the builtin interpreter
+func IsValid(name string) bool {
+	return len(name) == 0 || shells[name]
+}
+
+// Command is a command that runs in a shell
+type Command struct {
+	Shell  string
+	Cmd    string
+	Dir    string
+	Env    []string
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+
+	// TTY runs the command with a pseudo-terminal, the output of the
+	// terminal is written to Stdout and Stdin is forwarded to it
+	TTY bool
+
+	// GracePeriod is the time the programs have to stop after they receive
+	// SIGTERM before they are killed, DefaultGracePeriod is used if not set
+	GracePeriod time.Duration
+
+	// Limits are the resources that the programs of the command can use
+	Limits *Limits
+}
+
+// Run runs a command in its shell, when the command fails with an exit code
+// the error is an interp.ExitStatus, or an interp.ShellExitStatus if the
+// builtin interpreter ran the exit builtin
+func Run(ctx context.Context, c Command) error {
+	if c.TTY {
+		return runTTY(ctx, c)
+	}
+
+	if len(c.Shell) == 0 || c.Shell == Builtin {
+		return runBuiltin(ctx, c)
+	}
+
+	return runSystem(ctx, c, nil)
+}
+
+func runBuiltin(ctx context.Context, c Command) error {
+	p, err := syntax.NewParser().Parse(strings.NewReader(c.Cmd), "")
+	if err != nil {
+		return err
+	}
+
+	// The terminal of an interactive command is the controlling terminal of
+	// the programs that use it
+	var ctty *os.File
+	if c.TTY {
+		ctty, _ = c.Stdin.(*os.File)
+	}
+
+	r, err := interp.New(
+		interp.Dir(c.Dir),
+
+		interp.Env(expand.ListEnviron(c.Env...)),
+
+		interp.Module(execModule(c.GracePeriod, c.Limits, ctty)),
+		interp.Module(interp.OpenDevImpls(interp.DefaultOpen)),
+
+		interp.StdIO(c.Stdin, c.Stdout, c.Stderr),
+	)
+	if err != nil {
+		return err
+	}
+
+	return r.Run(ctx, p)
+}
+
+// runSystem runs the command with the shell installed in the system, the
+// shell and the programs it starts are stopped as a process group
+func runSystem(ctx context.Context, c Command, attr *syscall.SysProcAttr) error {
+	path, err := exec.LookPath(c.Shell)
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.Cmd{
+		Path:        path,
+		Args:        []string{c.Shell, "-c", c.Cmd},
+		Env:         c.Env,
+		Dir:         c.Dir,
+		Stdin:       c.Stdin,
+		Stdout:      c.Stdout,
+		Stderr:      c.Stderr,
+		SysProcAttr: attr,
+	}
+
+	err = start(ctx, &cmd, c.GracePeriod, c.Limits)
+	if err, ok := exitStatus(ctx, err); ok {
+		return err
+	}
+
+	if err != nil {
+		return fmt.Errorf("%s: %v", c.Shell, err)
+	}
+
+	return nil
+}
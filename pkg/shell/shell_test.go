@@ -0,0 +1,175 @@
+package shell
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"mvdan.cc/sh/interp"
+)
+
+func getShells(t *testing.T) []string {
+	result := []string{Builtin}
+	for _, name := range []string{"bash", "sh", "zsh"} {
+		if _, err := exec.LookPath(name); err == nil {
+			result = append(result, name)
+		} else {
+			t.Logf("The shell '%s' is not installed", name)
+		}
+	}
+
+	return result
+}
+
+func TestRun(t *testing.T) {
+	dir, err := ioutil.TempDir("", "elk")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	dir, err = filepath.EvalSymlinks(dir)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	for _, name := range getShells(t) {
+		var out bytes.Buffer
+		err := Run(context.Background(), Command{
+			Shell:  name,
+			Cmd:    "echo $FOO; pwd",
+			Dir:    dir,
+			Env:    []string{"FOO=BAR", "PATH=" + os.Getenv("PATH")},
+			Stdout: &out,
+			Stderr: ioutil.Discard,
+		})
+		if err != nil {
+			t.Errorf("The shell '%s' returns an unexpected error: %v", name, err)
+			continue
+		}
+
+		expected := "BAR\n" + dir + "\n"
+		if out.String() != expected {
+			t.Errorf("The output of shell '%s' should be '%s' but it was '%s' instead", name, expected, out.String())
+		}
+	}
+}
+
+func TestRunExitStatus(t *testing.T) {
+	for _, name := range getShells(t) {
+		err := Run(context.Background(), Command{
+			Shell:  name,
+			Cmd:    "exit 3",
+			Env:    []string{"PATH=" + os.Getenv("PATH")},
+			Stdout: ioutil.Discard,
+			Stderr: ioutil.Discard,
+		})
+
+		status, ok := err.(interp.ExitStatus)
+		if shellStatus, isShellStatus := err.(interp.ShellExitStatus); isShellStatus {
+			status, ok = interp.ExitStatus(shellStatus), true
+		}
+
+		if !ok || status != 3 {
+			t.Errorf("The shell '%s' should return the exit status %d but it returns %v instead", name, 3, err)
+		}
+	}
+}
+
+func TestRunCancel(t *testing.T) {
+	for _, name := range getShells(t) {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+
+		start := time.Now()
+		err := Run(ctx, Command{
+			Shell: name,
+			Cmd:   "sleep 5",
+			Env:   []string{"PATH=" + os.Getenv("PATH")},
+		})
+		cancel()
+
+		if err == nil {
+			t.Errorf("The shell '%s' should return an error when is cancelled", name)
+		}
+
+		if time.Since(start) > 3*time.Second {
+			t.Errorf("The shell '%s' should stop when is cancelled", name)
+		}
+	}
+}
+
+func TestIsValid(t *testing.T) {
+	for _, name := range []string{"", Builtin, "bash", "sh", "zsh"} {
+		if !IsValid(name) {
+			t.Errorf("The shell '%s' should be valid", name)
+		}
+	}
+
+	if IsValid("fish") {
+		t.Errorf("The shell '%s' should not be valid", "fish")
+	}
+}
+
+func TestRunTTY(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Pseudo-terminals are not supported on windows")
+	}
+
+	for _, name := range getShells(t) {
+		var out bytes.Buffer
+		err := Run(context.Background(), Command{
+			Shell:  name,
+			Cmd:    "tty",
+			Env:    []string{"PATH=" + os.Getenv("PATH")},
+			Stdout: &out,
+			TTY:    true,
+		})
+		if err != nil {
+			t.Errorf("The shell '%s' returns an unexpected error: %v", name, err)
+			continue
+		}
+
+		if !strings.HasPrefix(out.String(), "/dev/") {
+			t.Errorf("The shell '%s' should run the command in a terminal but the output was '%s'", name, out.String())
+		}
+	}
+}
+
+func TestRunTTYRead(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Pseudo-terminals are not supported on windows")
+	}
+
+	for _, name := range getShells(t) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+
+		var out bytes.Buffer
+		err := Run(ctx, Command{
+			Shell:  name,
+			Cmd:    "sh -c 'read x < /dev/tty; echo read $x'",
+			Env:    []string{"PATH=" + os.Getenv("PATH")},
+			Stdin:  strings.NewReader("abc\n"),
+			Stdout: &out,
+			TTY:    true,
+		})
+		cancel()
+
+		if err != nil {
+			t.Errorf("The shell '%s' returns an unexpected error: %v", name, err)
+			continue
+		}
+
+		if !strings.Contains(out.String(), "read abc") {
+			t.Errorf("The shell '%s' should read from /dev/tty but the output was '%s'", name, out.String())
+		}
+	}
+}
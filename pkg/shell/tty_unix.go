@@ -0,0 +1,164 @@
+// +build !windows
+
+package shell
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/creack/pty"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// stdin forwards the input of the user to the terminal of the command that is
+// running, os.Stdin is read by a single goroutine so no input is lost between
+// commands
+var stdin = &input{}
+
+type input struct {
+	once sync.Once
+	mu   sync.Mutex
+	w    io.Writer
+}
+
+// attach sends the input of a reader to a writer until the returned function
+// is called
+func (i *input) attach(r io.Reader, w io.Writer) func() {
+	if r == nil {
+		return func() {}
+	}
+
+	if r != os.Stdin {
+		go func() { _, _ = io.Copy(w, r) }()
+		return func() {}
+	}
+
+	i.mu.Lock()
+	i.w = w
+	i.mu.Unlock()
+
+	i.once.Do(func() {
+		go i.read(r)
+	})
+
+	return func() {
+		i.mu.Lock()
+		i.w = nil
+		i.mu.Unlock()
+	}
+}
+
+func (i *input) read(r io.Reader) {
+	buf := make([]byte, 1024)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			i.mu.Lock()
+			if i.w != nil {
+				_, _ = i.w.Write(buf[:n])
+			}
+			i.mu.Unlock()
+		}
+
+		if err != nil {
+			return
+		}
+	}
+}
+
+// runTTY runs a command with a pseudo-terminal, when Stdin is the terminal of
+// the user it is set in raw mode so the keys go straight to the command
+func runTTY(ctx context.Context, c Command) error {
+	ptmx, tty, err := pty.Open()
+	if err != nil {
+		return err
+	}
+	defer ptmx.Close()
+
+	if f, ok := c.Stdin.(*os.File); ok && terminal.IsTerminal(int(f.Fd())) {
+		fd := int(f.Fd())
+
+		resize := func() {
+			width, height, err := terminal.GetSize(fd)
+			if err == nil {
+				_ = pty.Setsize(ptmx, &pty.Winsize{Rows: uint16(height), Cols: uint16(width)})
+			}
+		}
+		resize()
+
+		winch := make(chan os.Signal, 1)
+		signal.Notify(winch, syscall.SIGWINCH)
+		defer signal.Stop(winch)
+		go func() {
+			for range winch {
+				resize()
+			}
+		}()
+
+		state, err := terminal.MakeRaw(fd)
+		if err == nil {
+			defer func() { _ = terminal.Restore(fd, state) }()
+		}
+	}
+
+	detach := stdin.attach(c.Stdin, ptmx)
+	defer detach()
+
+	output := make(chan struct{})
+	go func() {
+		_, _ = io.Copy(c.Stdout, ptmx)
+		close(output)
+	}()
+
+	cmd := c
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = tty, tty, tty
+
+	if len(c.Shell) == 0 || c.Shell == Builtin {
+		err = runBuiltin(ctx, cmd)
+	} else {
+		// The command leads a new session with the terminal as its controlling
+		// terminal, which is required by programs that read from /dev/tty
+		err = runSystem(ctx, cmd, &syscall.SysProcAttr{
+			Setsid:  true,
+			Setctty: true,
+		})
+	}
+
+	_ = tty.Close()
+
+	// The output ends when every process that uses the terminal closes it
+	select {
+	case <-output:
+	case <-time.After(DefaultGracePeriod):
+	}
+
+	return err
+}
+
+// terminalSession starts a program in a new session with tty as its
+// controlling terminal, which is required by programs that read from
+// /dev/tty. The program has to use tty as stdin, stdout or stderr, otherwise
+// it returns nil
+func terminalSession(cmd *exec.Cmd, tty *os.File) *syscall.SysProcAttr {
+	if tty == nil {
+		return nil
+	}
+
+	for fd, f := range []interface{}{cmd.Stdin, cmd.Stdout, cmd.Stderr} {
+		if f == interface{}(tty) {
+			return &syscall.SysProcAttr{
+				Setsid:  true,
+				Setctty: true,
+				Ctty:    fd,
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,20 @@
+package shell
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// runTTY runs a command with the console of the user, pseudo-terminals are not
+// supported on windows
+func runTTY(ctx context.Context, c Command) error {
+	c.TTY = false
+	return Run(ctx, c)
+}
+
+// terminalSession returns nil because there are no sessions on windows
+func terminalSession(cmd *exec.Cmd, tty *os.File) *syscall.SysProcAttr {
+	return nil
+}
@@ -6,7 +6,12 @@ import "strings"
 func RemoveDetachedFlag(args []string) []string {
 	var cmd []string
 
-	for _, arg := range args {
+	for i, arg := range args {
+		// The arguments after -- are the args of the task
+		if arg == "--" {
+			return append(cmd, args[i:]...)
+		}
+
 		if len(arg) > 0 && arg != "-d" && arg != "--detached" {
 			cmd = append(cmd, strings.TrimSpace(arg))
 		}
@@ -15,3 +15,14 @@ func TestRemoveDetachedFlag(t *testing.T) {
 		t.Errorf("The command should be '%s' but it is '%s' instead", expectedCmd, cmd)
 	}
 }
+
+func TestRemoveDetachedFlagWithTaskArgs(t *testing.T) {
+	args := []string{"ox", "run", "test", "-d", "--", "-d"}
+	args = RemoveDetachedFlag(args)
+
+	expectedCmd := "ox run test -- -d"
+	cmd := strings.Join(args, " ")
+	if cmd != expectedCmd {
+		t.Errorf("The command should be '%s' but it is '%s' instead", expectedCmd, cmd)
+	}
+}
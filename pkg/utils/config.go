@@ -6,6 +6,7 @@ import (
 	"os"
 	"os/user"
 	"path"
+	"path/filepath"
 
 	"github.com/jjzcru/elk/pkg/primitives/ox"
 )
@@ -17,62 +18,116 @@ func GetElk(filePath string, isGlobal bool) (*ox.Elk, error) {
 
 	if len(filePath) > 0 {
 		elkConfigPath = filePath
+		isGlobal = false
 	} else {
-		elkConfigPath, err = getElkFilePath(isGlobal)
+		elkConfigPath, isGlobal, err = getElkFilePath(isGlobal)
 		if err != nil {
-			return nil, err
+			return nil, &ConfigError{Err: err}
 		}
 	}
 
 	response, err := ox.FromFile(elkConfigPath)
 	if err != nil {
-		return nil, err
+		return nil, &ConfigError{Err: err}
 	}
 
 	response.SetFilePath(elkConfigPath)
+	response.SetGlobal(isGlobal)
 
 	return response, nil
 }
 
+// GetElkFilePath get the path of the ox file without loading it
+func GetElkFilePath(filePath string, isGlobal bool) (string, error) {
+	if len(filePath) > 0 {
+		return filePath, nil
+	}
+
+	elkFilePath, _, err := getElkFilePath(isGlobal)
+	if err != nil {
+		return "", &ConfigError{Err: err}
+	}
+
+	return elkFilePath, nil
+}
+
 // SetElk saves elk object in a file
 func SetElk(elk *ox.Elk, filePath string) error {
 	return ox.ToFile(elk, filePath)
 }
 
-func getElkFilePath(isGlobal bool) (string, error) {
+// getElkFilePath returns the path of the nearest ox file from the current
+// directory or its parents, or the global file, and if it is the global file
+func getElkFilePath(isGlobal bool) (string, bool, error) {
+	if isGlobal {
+		elkFilePath, err := getGlobalElkFile()
+		if err != nil {
+			return "", false, err
+		}
+		return elkFilePath, true, nil
+	}
+
 	dir, err := os.Getwd()
 	if err != nil {
-		return "", err
+		return "", false, err
 	}
 
-	var elkFilePath string
-
-	if isGlobal {
+	elkFilePath, ok := findElkFile(dir)
+	if !ok {
 		elkFilePath, err = getGlobalElkFile()
 		if err != nil {
-			return "", err
+			return "", false, err
 		}
-		return elkFilePath, nil
+		return elkFilePath, true, nil
 	}
 
-	isLocal := isLocalElkFile(path.Join(dir, "ox.yml"))
-	if isLocal {
-		elkFilePath = path.Join(dir, "ox.yml")
-	} else {
-		elkFilePath, err = getGlobalElkFile()
-		if err != nil {
-			return "", err
-		}
+	// The global file can be found while walking up, like ~/ox.yml from a
+	// directory inside home
+	globalElkFilePath, err := getGlobalElkFile()
+	if err == nil && isSameFile(elkFilePath, globalElkFilePath) {
+		return elkFilePath, true, nil
 	}
 
-	return elkFilePath, nil
+	return elkFilePath, false, nil
+}
+
+// findElkFile walks up from dir to the root of the filesystem and returns the
+// path of the first ox.yml that it finds
+func findElkFile(dir string) (string, bool) {
+	for {
+		elkFilePath := filepath.Join(dir, "ox.yml")
+		if isLocalElkFile(elkFilePath) {
+			return elkFilePath, true
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
 }
 
 func isLocalElkFile(localDirectory string) bool {
-	if _, err := os.Stat(localDirectory); os.IsNotExist(err) {
+	info, err := os.Stat(localDirectory)
+	if err != nil {
 		return false
 	}
-	return true
+	return !info.IsDir()
+}
+
+func isSameFile(a string, b string) bool {
+	infoA, err := os.Stat(a)
+	if err != nil {
+		return false
+	}
+
+	infoB, err := os.Stat(b)
+	if err != nil {
+		return false
+	}
+
+	return os.SameFile(infoA, infoB)
 }
 
 func getGlobalElkFile() (string, error) {
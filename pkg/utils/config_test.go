@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindElkFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "elk-config-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	subDir := filepath.Join(dir, "foo", "bar")
+	err = os.MkdirAll(subDir, os.ModePerm)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	elkFilePath := filepath.Join(dir, "ox.yml")
+	err = ioutil.WriteFile(elkFilePath, []byte("version: 1"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path, ok := findElkFile(subDir)
+	if !ok {
+		t.Fatalf("The file should be found from '%s'", subDir)
+	}
+
+	if path != elkFilePath {
+		t.Errorf("The path should be '%s' but it was '%s'", elkFilePath, path)
+	}
+}
+
+func TestFindElkFileNearest(t *testing.T) {
+	dir, err := ioutil.TempDir("", "elk-config-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	subDir := filepath.Join(dir, "foo", "bar")
+	err = os.MkdirAll(subDir, os.ModePerm)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range []string{dir, filepath.Join(dir, "foo")} {
+		err = ioutil.WriteFile(filepath.Join(path, "ox.yml"), []byte("version: 1"), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A directory named ox.yml is not an ox file
+	err = os.Mkdir(filepath.Join(subDir, "ox.yml"), os.ModePerm)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path, ok := findElkFile(subDir)
+	if !ok {
+		t.Fatalf("The file should be found from '%s'", subDir)
+	}
+
+	expected := filepath.Join(dir, "foo", "ox.yml")
+	if path != expected {
+		t.Errorf("The path should be '%s' but it was '%s'", expected, path)
+	}
+}
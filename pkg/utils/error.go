@@ -1,6 +1,8 @@
 package utils
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"github.com/logrusorgru/aurora"
 	"os"
@@ -8,9 +10,15 @@ import (
 
 // PrintError display the error message in the cli
 func PrintError(err error) {
-	if err.Error() != "context canceled" && err.Error() != "context deadline exceeded" {
+	if !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded) {
 		fmt.Print(aurora.Bold(aurora.Red("ERROR: ")))
 		_, _ = fmt.Fprint(os.Stderr, err.Error())
 		fmt.Println()
 	}
 }
+
+// PrintSkipped display in the cli that a task was skipped
+func PrintSkipped(task string) {
+	fmt.Print(aurora.Bold(aurora.Yellow("SKIPPED: ")))
+	fmt.Println(task)
+}
@@ -0,0 +1,122 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"github.com/jjzcru/elk/pkg/engine"
+	"github.com/jjzcru/elk/pkg/primitives/ox"
+)
+
+// Exit codes of the cli, when a command of a task fails the cli exits with
+// the exit code of the command instead
+const (
+	ExitSuccess            = 0
+	ExitFailure            = 1
+	ExitInvalidArg         = 2
+	ExitTaskNotFound       = 64
+	ExitCircularDependency = 65
+	ExitConfig             = 78
+	ExitTimeout            = 124
+	ExitCancelled          = 130
+)
+
+// ConfigError is the error returned when the ox file can not be loaded
+type ConfigError struct {
+	Err error
+}
+
+func (e *ConfigError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the error from the ox file
+func (e *ConfigError) Unwrap() error {
+	return e.Err
+}
+
+// ExitError is an error with the exit code of the cli
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the error that ends the cli
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// NewExitError returns the error of a run with its exit code, ctx is the
+// context of the run and it is used to know if it was cancelled or timed out
+func NewExitError(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &ExitError{
+		Code: ExitCode(ctx, err),
+		Err:  err,
+	}
+}
+
+// ExitCode returns the exit code of the cli for an error
+func ExitCode(ctx context.Context, err error) int {
+	if err == nil {
+		return ExitSuccess
+	}
+
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+
+	var configErr *ConfigError
+	switch {
+	case errors.Is(err, ox.ErrCircularDependency):
+		return ExitCircularDependency
+	case errors.Is(err, ox.ErrTaskNotFound):
+		return ExitTaskNotFound
+	case errors.Is(err, ox.ErrInvalidArgValue),
+		errors.Is(err, ox.ErrUnknownArg),
+		errors.Is(err, ox.ErrMissingArg):
+		return ExitInvalidArg
+	case errors.As(err, &configErr):
+		return ExitConfig
+	}
+
+	status, exitCode := engine.GetStatus(ctx, err)
+	switch status {
+	case engine.StatusTimeout:
+		return ExitTimeout
+	case engine.StatusCancelled:
+		if ctx.Err() == context.DeadlineExceeded {
+			return ExitTimeout
+		}
+		return ExitCancelled
+	default:
+		return exitCode
+	}
+}
+
+// Exit display the error in the cli and exits with its exit code
+func Exit(err error) {
+	if err == nil {
+		return
+	}
+
+	var errs engine.Errors
+	if errors.As(err, &errs) {
+		for _, err := range errs {
+			PrintError(err)
+		}
+	} else {
+		PrintError(err)
+	}
+
+	os.Exit(ExitCode(context.Background(), err))
+}
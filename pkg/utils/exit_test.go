@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jjzcru/elk/pkg/engine"
+	"github.com/jjzcru/elk/pkg/primitives/ox"
+	"mvdan.cc/sh/interp"
+)
+
+func TestExitCode(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	expired, cancel := context.WithTimeout(context.Background(), 0)
+	defer cancel()
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		err      error
+		exitCode int
+	}{
+		{"success", context.Background(), nil, ExitSuccess},
+		{"failure", context.Background(), errors.New("failure"), ExitFailure},
+		{"command", context.Background(), &engine.TaskError{Task: "build", Err: interp.ExitStatus(3)}, 3},
+		{"first command", context.Background(), engine.Errors{interp.ExitStatus(7), interp.ExitStatus(3)}, 7},
+		{"timeout", context.Background(), &engine.TimeoutError{Task: "build", Timeout: time.Second}, ExitTimeout},
+		{"deadline", expired, context.DeadlineExceeded, ExitTimeout},
+		{"cancelled", cancelled, context.Canceled, ExitCancelled},
+		{"task not found", context.Background(), fmt.Errorf("%w: %s", ox.ErrTaskNotFound, "build"), ExitTaskNotFound},
+		{"invalid arg", context.Background(), fmt.Errorf("task '%s': %w", "deploy", ox.ErrMissingArg), ExitInvalidArg},
+		{"circular dependency", context.Background(), &ConfigError{Err: ox.ErrCircularDependency}, ExitCircularDependency},
+		{"config", context.Background(), &ConfigError{Err: ox.ErrInvalidShell}, ExitConfig},
+		{"exit error", context.Background(), &ExitError{Code: 42, Err: errors.New("failure")}, 42},
+	}
+
+	for _, test := range tests {
+		exitCode := ExitCode(test.ctx, test.err)
+		if exitCode != test.exitCode {
+			t.Errorf("The exit code for '%s' should be %d but it was %d instead", test.name, test.exitCode, exitCode)
+		}
+	}
+}